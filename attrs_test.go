@@ -0,0 +1,69 @@
+package htm
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Attrs_Allowed(t *testing.T) {
+	for _, c := range []struct {
+		tag, name string
+		want      bool
+	}{
+		{"div", "id", true},
+		{"div", "popover", true},
+		{"td", "colspan", true},
+		{"td", "href", false},
+		{"input", "colspan", false},
+		{"button", "popovertarget", true},
+		{"span", "data-x", true},
+		{"span", "aria-label", true},
+		{"span", "onclick", true},
+		{"my-widget", "anything", true},
+		{"div", "unknown", false},
+	} {
+		if got := AttrAllowed(c.tag, c.name); got != c.want {
+			t.Fatalf("unexpected for %s %s:\n got: %v\nwant: %v", c.tag, c.name, got, c.want)
+		}
+	}
+}
+
+func Test_Attrs_Generated(t *testing.T) {
+	n := Button(PopoverTarget("menu"), PopoverTargetAction(PopoverTargetActionToggle), FormMethod(MethodPost))
+	want := `<button popovertarget="menu" popovertargetaction="toggle" formmethod="post"></button>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	n = Search(Popover(PopoverManual), Hidden())
+	want = `<search popover="manual" hidden></search>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Attrs_Keywords(t *testing.T) {
+	custom := "shipping street-address"
+	n := Input(InputType(InputTypeEmail), Autocomplete(AutocompleteCurrentPassword), InputMode(InputModeEmail),
+		EnterKeyHint(EnterKeyHintSend)).Autocomplete(AutocompleteKeyword(custom))
+	want := `<input type="email" autocomplete="shipping street-address" inputmode="email" enterkeyhint="send"/>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	n = Img(Loading(LoadingLazy), Decoding(DecodingAsync), CrossOrigin(CrossOriginUseCredentials),
+		ReferrerPolicy(ReferrerPolicyStrictOriginWhenCrossOrigin))
+	want = `<img loading="lazy" decoding="async" crossorigin="use-credentials" referrerpolicy="strict-origin-when-cross-origin"/>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Attrs_MinMaxDate(t *testing.T) {
+	n := Input().InputType(InputTypeDate).
+		MinDate(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)).
+		MaxDate(time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	defer n.Release()
+	want := `<input type="date" min="0001-01-01" max="9999-12-31"/>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
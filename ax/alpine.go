@@ -44,16 +44,6 @@ func SetInit(n *htm.Node, value ...htm.TypedValue) {
 	}
 }
 
-// Show toggles the visibility of an element based on the truthiness of a JavaScript expression.
-func Show(v string) htm.Mod {
-	return htm.Attr("x-show", v)
-}
-
-// SetShow sets the x-show attribute on the node.
-func SetShow(n *htm.Node, v string) {
-	n.Attr("x-show", v)
-}
-
 /**/
 
 // Bind sets the value of an attribute to the result of a JavaScript expression.
@@ -92,38 +82,6 @@ func SetOn(n *htm.Node, event string, v string) {
 
 /**/
 
-// Text sets the text content of an element to the result of a JavaScript expression.
-func Text(v string) htm.Mod {
-	return htm.Attr("x-text", v)
-}
-
-// SetText sets the x-text attribute on the node.
-func SetText(n *htm.Node, v string) {
-	n.Attr("x-text", v)
-}
-
-// HTML sets the inner HTML of an element to the result of a JavaScript expression.
-func HTML(v string) htm.Mod {
-	return htm.Attr("x-html", v)
-}
-
-// SetHTML sets the x-html attribute on the node.
-func SetHTML(n *htm.Node, v string) {
-	n.Attr("x-html", v)
-}
-
-/**/
-
-// Model binds the value of an input element to a specific data property.
-func Model(v string) htm.Mod {
-	return htm.Attr("x-model", v)
-}
-
-// SetModel sets the x-model attribute on the node.
-func SetModel(n *htm.Node, v string) {
-	n.Attr("x-model", v)
-}
-
 // ModelValue binds the value of an input element to a specific data property.
 // It also allows to pass optional modifiers.
 func ModelValue(modifiers string, v htm.TypedValue) htm.Mod {
@@ -143,103 +101,6 @@ func SetModelValue(n *htm.Node, modifiers string, v htm.TypedValue) {
 	}
 }
 
-// Modelable allows to expose any Alpine property as the target of an x-model directive.
-func Modelable(v string) htm.Mod {
-	return htm.Attr("x-modelable", v)
-}
-
-// SetModelable sets the x-modelable attribute on the node.
-func SetModelable(n *htm.Node, v string) {
-	n.Attr("x-modelable", v)
-}
-
-/**/
-
-// For allows to iterate over an array or object to create DOM elements.
-// Must be used on a <template> tag.
-func For(v string) htm.Mod {
-	return htm.Attr("x-for", v)
-}
-
-// SetFor sets the x-for attribute on the node.
-func SetFor(n *htm.Node, v string) {
-	n.Attr("x-for", v)
-}
-
-// If allows to toggle an element in and out of the DOM based on a JS expression.
-// Must be used on a <template> tag.
-func If(v string) htm.Mod {
-	return htm.Attr("x-if", v)
-}
-
-// SetIf sets the x-if attribute on the node.
-func SetIf(n *htm.Node, v string) {
-	n.Attr("x-if", v)
-}
-
-/**/
-
-// Effect allows to react to changes in data by running a callback.
-func Effect(v string) htm.Mod {
-	return htm.Attr("x-effect", v)
-}
-
-// SetEffect sets the x-effect attribute on the node.
-func SetEffect(n *htm.Node, v string) {
-	n.Attr("x-effect", v)
-}
-
-// Ref allows to reference elements directly in JavaScript using $refs.
-func Ref(name string) htm.Mod {
-	return htm.Attr("x-ref", name)
-}
-
-// SetRef sets the x-ref attribute on the node.
-func SetRef(n *htm.Node, name string) {
-	n.Attr("x-ref", name)
-}
-
-// Cloak hides the element until Alpine has fully initialized.
-func Cloak(v ...bool) htm.Mod {
-	return htm.AttrBool("x-cloak", v...)
-}
-
-// SetCloak sets the x-cloak attribute on the node.
-func SetCloak(n *htm.Node, v ...bool) {
-	n.AttrBool("x-cloak", v...)
-}
-
-// Ignore prevents Alpine from initializing elements within a block of HTML.
-func Ignore(v ...bool) htm.Mod {
-	return htm.AttrBool("x-ignore", v...)
-}
-
-// SetIgnore sets the x-ignore attribute on the node.
-func SetIgnore(n *htm.Node, v ...bool) {
-	n.AttrBool("x-ignore", v...)
-}
-
-// ID allows to declare a new scope for $id().
-func ID(v string) htm.Mod {
-	return htm.Attr("x-id", v)
-}
-
-// SetID sets the x-id attribute on the node.
-func SetID(n *htm.Node, v string) {
-	n.Attr("x-id", v)
-}
-
-// Teleport allows to transport part of template to another part of the DOM.
-// selector: the DOM selector to append the content to (e.g. "body").
-func Teleport(selector string) htm.Mod {
-	return htm.Attr("x-teleport", selector)
-}
-
-// SetTeleport sets the x-teleport attribute on the node.
-func SetTeleport(n *htm.Node, selector string) {
-	n.Attr("x-teleport", selector)
-}
-
 /**/
 
 // Transition enables standard transitions on an element.
@@ -0,0 +1,192 @@
+package htm
+
+import (
+	"errors"
+	"io"
+	"unsafe"
+)
+
+// Comment creates an HTML comment node. The text is escaped, so it cannot close the comment:
+// "<" and ">" are written as character references and a space is inserted between consecutive dashes.
+// Comments can be dropped from the output with Renderer.StripComments.
+func Comment(text string) *Node {
+	n := Get()
+	n.tag = "$comment"
+	n.value = String(text)
+	n.writeFn = renderComment
+	return n
+}
+
+// ConditionalComment wraps nodes into a downlevel-hidden conditional comment,
+// e.g. <!--[if IE]>...<![endif]-->. The condition may only contain letters, digits,
+// spaces and the characters "!()&|". Conditional comments are dropped with Renderer.StripComments.
+func ConditionalComment(cond string, nodes ...*Node) *Node {
+	n := Get()
+	n.tag = "$comment"
+	n.value = String(cond)
+	n.content = append(n.content, nodes...)
+	n.writeFn = renderConditionalComment
+	return n
+}
+
+// Marker creates a named marker comment, e.g. <!--list-start-->,
+// as used by DOM morphing libraries and as placeholders for streamed content.
+// Unlike comments, markers are kept by Renderer.StripComments.
+// Names may only contain letters, digits and the characters "-_:./".
+func Marker(name string) *Node {
+	n := Get()
+	n.tag = "$marker"
+	n.value = String(name)
+	n.writeFn = renderMarker
+	return n
+}
+
+// Marked wraps nodes into a pair of marker comments: <!--name-->...<!--/name-->.
+func Marked(name string, nodes ...*Node) *Node {
+	n := Marker(name)
+	n.content = append(n.content, nodes...)
+	return n
+}
+
+var (
+	commentOpen     = []byte("<!-- ")
+	commentClose    = []byte(" -->")
+	markerOpen      = []byte("<!--")
+	markerEndOpen   = []byte("<!--/")
+	markerClose     = []byte("-->")
+	conditionOpen   = []byte("<!--[if ")
+	conditionClose  = []byte("]>")
+	conditionEndTag = []byte("<![endif]-->")
+
+	errInvalidMarker    = errors.New("htm: invalid marker name")
+	errInvalidCondition = errors.New("htm: invalid conditional comment")
+)
+
+func renderComment(n *Node, w io.Writer) error {
+	if r := ContextOf(w).renderer(); r != nil && r.StripComments {
+		return nil
+	}
+	if _, err := w.Write(commentOpen); err != nil {
+		return err
+	}
+	s := unsafe.Slice(n.value.any.(stringptr), n.value.num)
+	if _, err := CommentEscapeWriter(w.Write).Write(s); err != nil {
+		return err
+	}
+	_, err := w.Write(commentClose)
+	return err
+}
+
+func renderConditionalComment(n *Node, w io.Writer) error {
+	if r := ContextOf(w).renderer(); r != nil && r.StripComments {
+		return nil
+	}
+	cond := n.value.StringOrZero()
+	if !validCondition(cond) {
+		return errInvalidCondition
+	}
+	if _, err := w.Write(conditionOpen); err != nil {
+		return err
+	}
+	if _, err := WriteString(w, cond); err != nil {
+		return err
+	}
+	if _, err := w.Write(conditionClose); err != nil {
+		return err
+	}
+	if err := renderGroup(n, w); err != nil {
+		return err
+	}
+	_, err := w.Write(conditionEndTag)
+	return err
+}
+
+func renderMarker(n *Node, w io.Writer) error {
+	name := n.value.StringOrZero()
+	if !ValidMarker(name) {
+		return errInvalidMarker
+	}
+	if err := writeMarker(w, markerOpen, name); err != nil {
+		return err
+	}
+	if len(n.content) == 0 {
+		return nil
+	}
+	if err := renderGroup(n, w); err != nil {
+		return err
+	}
+	return writeMarker(w, markerEndOpen, name)
+}
+
+func writeMarker(w io.Writer, open []byte, name string) error {
+	if _, err := w.Write(open); err != nil {
+		return err
+	}
+	if _, err := WriteString(w, name); err != nil {
+		return err
+	}
+	_, err := w.Write(markerClose)
+	return err
+}
+
+// ValidMarker checks if the string can be used as a Marker name.
+func ValidMarker(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == ':' || c == '.' || c == '/' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func validCondition(cond string) bool {
+	if cond == "" {
+		return false
+	}
+	for i := 0; i < len(cond); i++ {
+		c := cond[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+			c == ' ' || c == '!' || c == '(' || c == ')' || c == '&' || c == '|' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// CommentEscapeWriter escapes text written into an HTML comment.
+// It works as EscapeWriter and also inserts a space between consecutive dashes,
+// so the text can contain neither "-->" nor "--", which is not allowed in XML comments.
+type CommentEscapeWriter func(p []byte) (n int, err error)
+
+var dashSpace = []byte("- ")
+
+func (w CommentEscapeWriter) Write(p []byte) (int, error) {
+	start := 0
+	for i := 0; i+1 < len(p); i++ {
+		if p[i] != '-' || p[i+1] != '-' {
+			continue
+		}
+		if start < i {
+			if _, err := EscapeWriter(w).Write(p[start:i]); err != nil {
+				return start, err
+			}
+		}
+		if _, err := w(dashSpace); err != nil {
+			return i, err
+		}
+		start = i + 1
+	}
+	if start < len(p) {
+		if _, err := EscapeWriter(w).Write(p[start:]); err != nil {
+			return start, err
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,38 @@
+package htm
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_Comment_EscapingMarkersAndConditions(t *testing.T) {
+	n := Div().Content(
+		Comment("a---b --> <!-- c-"),
+		Marked("list", Li().Text("x")),
+		Marker("slot:1"),
+		ConditionalComment("lt IE 9", Script().Attr("src", "/shim.js")),
+	)
+	defer n.Release()
+
+	want := `<div><!-- a- - -b - -&gt; &lt;!- - c- --><!--list--><li>x</li><!--/list--><!--slot:1-->` +
+		`<!--[if lt IE 9]><script src="/shim.js"></script><![endif]--></div>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := (&Renderer{StripComments: true}).Render(&buf, n); err != nil {
+		t.Fatal(err)
+	}
+	want = `<div><!--list--><li>x</li><!--/list--><!--slot:1--></div>`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	for _, bad := range []*Node{Marker("a -->"), ConditionalComment("IE]><script>")} {
+		if err := bad.Render(&buf); err == nil {
+			t.Fatalf("expected error for %v", bad.value.StringOrZero())
+		}
+		bad.Release()
+	}
+}
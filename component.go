@@ -0,0 +1,157 @@
+package htm
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ComponentDef is a component with typed props, created by Component.
+type ComponentDef[P any] struct {
+	name     string
+	render   func(P, *Node) *Node
+	required []int // indexes of fields tagged `htm:"required"`
+	writeFn  func(*Node, io.Writer) error
+}
+
+type componentState[P any] struct {
+	props P
+	built bool
+}
+
+func (st *componentState[P]) isBuilt() bool { return st.built }
+
+// Component defines a component with props of type P.
+// The node passed to render is the host node created by New: it holds the children,
+// slots, classes, attributes and styles set by the caller. The content left in the host
+// after render is appended to the returned node, as well as classes, attributes and styles of the host;
+// slots are placed into SlotOutlets of the returned tree.
+//
+// Fields of P tagged with `htm:"required"` must not be zero when the component is rendered.
+//
+//	type ButtonProps struct {
+//		Caption string `htm:"required"`
+//		Icon    string
+//	}
+//
+//	var Button = htm.Component(func(p ButtonProps, n *htm.Node) *htm.Node {
+//		return htm.Button().Text(p.Caption)
+//	})
+//
+//	Button.New(Button.Props(ButtonProps{Caption: "Save"}), htm.Class("primary"))
+//
+// The render function is called once, when the host node is rendered for the first time.
+func Component[P any](render func(P, *Node) *Node) *ComponentDef[P] {
+	c := &ComponentDef[P]{render: render}
+	t := reflect.TypeFor[P]()
+	c.name = t.Name()
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Tag.Get("htm") == "required" {
+				c.required = append(c.required, i)
+			}
+		}
+	}
+	c.writeFn = c.renderHost
+	return c
+}
+
+// New creates a host node of the component and applies mods to it.
+func (c *ComponentDef[P]) New(mods ...Mod) *Node {
+	n := Get()
+	n.tag = "$component"
+	n.value = TypedValue{num: uint64(KindAny), any: &componentState[P]{}}
+	n.writeFn = c.writeFn
+	return n.Apply(mods)
+}
+
+// Props returns a Mod that sets the props of the component.
+func (c *ComponentDef[P]) Props(p P) Mod {
+	return func(n *Node) { c.state(n).props = p }
+}
+
+// With returns a Mod that modifies the props of the component.
+func (c *ComponentDef[P]) With(fn func(p *P)) Mod {
+	return func(n *Node) { fn(&c.state(n).props) }
+}
+
+// PropsOf returns the props of a host node of the component.
+func (c *ComponentDef[P]) PropsOf(n *Node) P {
+	return c.state(n).props
+}
+
+func (c *ComponentDef[P]) state(n *Node) *componentState[P] {
+	st, ok := n.value.any.(*componentState[P])
+	if !ok || n.tag != "$component" {
+		panic(fmt.Sprintf("htm: node is not a %v component", c.name))
+	}
+	return st
+}
+
+// Validate checks that all required props of the host node are set.
+func (c *ComponentDef[P]) Validate(n *Node) error {
+	st := c.state(n)
+	if len(c.required) == 0 {
+		return nil
+	}
+	v := reflect.ValueOf(&st.props).Elem()
+	for _, i := range c.required {
+		if v.Field(i).IsZero() {
+			return fmt.Errorf("htm: component %v: missing required prop %v", c.name, v.Type().Field(i).Name)
+		}
+	}
+	return nil
+}
+
+func (c *ComponentDef[P]) renderHost(n *Node, w io.Writer) error {
+	st := c.state(n)
+	if !st.built {
+		for _, fn := range n.postponed {
+			fn(n)
+		}
+		if err := c.Validate(n); err != nil {
+			return err
+		}
+		st.built = true
+		if out := c.render(st.props, n); out != nil {
+			c.place(n, out)
+		}
+	}
+	if len(n.slots) > 0 {
+		r := ContextOf(w).renderer()
+		if err := n.fillOutlets(r != nil && r.StrictSlots); err != nil {
+			return err
+		}
+	}
+	return renderGroup(n, w)
+}
+
+// place makes out the content of the host, moving the rest of the host state into it.
+func (c *ComponentDef[P]) place(n, out *Node) {
+	if out.writeFn != nil && out.tag != "$group" {
+		n.content = append([]*Node{out}, n.content...)
+		return
+	}
+	out.content = append(out.content, n.content...)
+	clear(n.content)
+	n.content = append(n.content[:0], out)
+
+	if out.writeFn != nil {
+		return
+	}
+	for _, e := range n.class.o {
+		if e.active {
+			out.class.setOne(e.name, true)
+		}
+	}
+	for _, e := range n.attrs.o {
+		if e.value.Valid() {
+			out.attrs.set(e.name, e.value)
+		}
+	}
+	for _, e := range n.style.o {
+		if e.value.Valid() {
+			out.style.set(e.name, e.value)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package htm
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+type testButtonProps struct {
+	Caption string `htm:"required"`
+	Icon    string
+}
+
+var testButton = Component(func(p testButtonProps, n *Node) *Node {
+	b := Button().Class("btn").Content(SlotOutlet("icon"))
+	if p.Icon != "" {
+		b.Append(I().Class(p.Icon))
+	}
+	return b.Append(Span().Text(p.Caption))
+})
+
+func Test_Component_PropsChildrenSlots(t *testing.T) {
+	n := testButton.New(
+		testButton.Props(testButtonProps{Caption: "Save"}),
+		testButton.With(func(p *testButtonProps) { p.Icon = "i-save" }),
+		Class("primary"),
+		AttrValue("tabindex", Int(2)),
+		Slot("icon", Text("*")),
+		Content(Text("!")),
+	)
+	defer n.Release()
+
+	if got := testButton.PropsOf(n).Caption; got != "Save" {
+		t.Fatalf("unexpected props: %q", got)
+	}
+
+	want := `<button class="btn primary" tabindex="2">*<i class="i-save"></i><span>Save</span>!</button>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected second render:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Component_MissingRequiredProp(t *testing.T) {
+	n := Div().Content(testButton.New(testButton.With(func(p *testButtonProps) { p.Icon = "x" })))
+	defer n.Release()
+
+	err := n.Render(io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "Caption") {
+		t.Fatalf("expected missing prop error, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package htm
+
+import (
+	"io"
+	"time"
+)
+
+// Context holds render-time state.
+// It travels together with the writer passed to Render,
+// so nodes with custom rendering logic can access it via ContextOf.
+type Context struct {
+	// Locale is used to resolve translatable text (see T),
+	// to format values in text and to set lang and dir attributes on the html element.
+	Locale *Locale
+
+	// TimeLayout overrides the locale layout used to render times in text.
+	TimeLayout string
+	// DateLayout overrides the locale layout used to render dates in text.
+	DateLayout string
+	// DurationFormat overrides the format of durations in text.
+	// It appends the formatted duration to b and returns the extended buffer.
+	DurationFormat func(b []byte, d time.Duration) []byte
+
+	// IDs generates IDs for NewID values rendered with this context.
+	// If nil, DefaultIDs is used.
+	IDs *IDGenerator
+
+	// Renderer holds render options and hooks, it is set by Renderer.Render.
+	Renderer *Renderer
+
+	depth        int     // nesting level for pretty printing
+	lines        int     // number of lines started, for pretty printing
+	preformatted int     // number of open elements with significant whitespace, for pretty printing
+	written      bool    // whether an element has been written, for pretty printing
+	open         []*Node // elements being rendered, tracked for the Validator
+	ascii        *asciiWriter
+}
+
+// writeIndent starts a new line indented to the current depth, except before the first element.
+func (ctx *Context) writeIndent(w io.Writer, indent string) error {
+	if !ctx.written {
+		ctx.written = true
+		return nil
+	}
+	ctx.lines++
+	if _, err := w.Write(newline); err != nil {
+		return err
+	}
+	for i := 0; i < ctx.depth; i++ {
+		if _, err := WriteString(w, indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var newline = []byte("\n")
+
+func (ctx *Context) renderer() *Renderer {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Renderer
+}
+
+func (ctx *Context) locale() *Locale {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Locale
+}
+
+func (ctx *Context) timeLayout() string {
+	if ctx != nil {
+		if ctx.TimeLayout != "" {
+			return ctx.TimeLayout
+		}
+		if ctx.Locale != nil && ctx.Locale.DateTimeLayout != "" {
+			return ctx.Locale.DateTimeLayout
+		}
+	}
+	return time.DateTime
+}
+
+func (ctx *Context) dateLayout() string {
+	if ctx != nil {
+		if ctx.DateLayout != "" {
+			return ctx.DateLayout
+		}
+		if ctx.Locale != nil && ctx.Locale.DateLayout != "" {
+			return ctx.Locale.DateLayout
+		}
+	}
+	return time.DateOnly
+}
+
+type contextWriter struct {
+	io.Writer
+	ctx *Context
+}
+
+// WithContext returns a writer that carries ctx through rendering.
+func WithContext(w io.Writer, ctx *Context) io.Writer {
+	if cw, ok := w.(*contextWriter); ok {
+		w = cw.Writer
+	}
+	return &contextWriter{Writer: w, ctx: ctx}
+}
+
+// WithLocale returns a writer that carries a render context with the given locale.
+func WithLocale(w io.Writer, l *Locale) io.Writer {
+	return WithContext(w, &Context{Locale: l})
+}
+
+// ContextOf returns the render context carried by w, or nil if there is none.
+func ContextOf(w io.Writer) *Context {
+	if cw, ok := w.(*contextWriter); ok {
+		return cw.ctx
+	}
+	return nil
+}
+
+// LocaleOf returns the locale of the render context carried by w, or nil.
+func LocaleOf(w io.Writer) *Locale {
+	if cw, ok := w.(*contextWriter); ok && cw.ctx != nil {
+		return cw.ctx.Locale
+	}
+	return nil
+}
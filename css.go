@@ -0,0 +1,261 @@
+package htm
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StyleSheet is a piece of component CSS with class names scoped by a hash suffix.
+type StyleSheet struct {
+	css     string
+	classes map[string]string // original name -> scoped name
+}
+
+var styleSheets struct {
+	mu     sync.RWMutex
+	list   []*StyleSheet
+	bySrc  map[string]*StyleSheet
+	bundle []byte
+	etag   string
+}
+
+// CSS rewrites class selectors of src to scoped names and registers the result
+// for output with Styles, WriteStyles or StylesHandler.
+// It is meant to be called once per component, usually in a package-level variable;
+// calling it again with the same src returns the registered stylesheet, so it is not duplicated in the bundle:
+//
+//	var cardCSS = htm.CSS(`.root { padding: 1rem } .label { font-weight: bold }`)
+//
+//	htm.Div(cardCSS.Scope(), htm.Class("root"), htm.Content(
+//		htm.Span().Class("label").Text("Title"),
+//	))
+//
+// Only class selectors outside of declaration blocks are rewritten;
+// nested style rules are not supported. The same src always produces the same names.
+func CSS(src string) *StyleSheet {
+	styleSheets.mu.RLock()
+	s, ok := styleSheets.bySrc[src]
+	styleSheets.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	h := fnv.New32a()
+	_, _ = io.WriteString(h, src)
+	suffix := "-" + strconv.FormatUint(uint64(h.Sum32()), 36)
+
+	s = &StyleSheet{classes: make(map[string]string)}
+	s.css = scopeCSS(src, func(name string) string {
+		// class names are matched without CSS escapes, e.g. "sm\:p-2" is "sm:p-2"
+		key := strings.ReplaceAll(name, `\`, "")
+		s.classes[key] = key + suffix
+		return name + suffix
+	})
+
+	styleSheets.mu.Lock()
+	defer styleSheets.mu.Unlock()
+	if registered, ok := styleSheets.bySrc[src]; ok {
+		return registered // registered concurrently
+	}
+	if styleSheets.bySrc == nil {
+		styleSheets.bySrc = make(map[string]*StyleSheet)
+	}
+	styleSheets.bySrc[src] = s
+	styleSheets.list = append(styleSheets.list, s)
+	styleSheets.bundle = nil
+	return s
+}
+
+// Class returns the scoped name of a class defined by the stylesheet.
+// Other names are returned unchanged.
+func (s *StyleSheet) Class(name string) string {
+	if scoped, ok := s.classes[name]; ok {
+		return scoped
+	}
+	return name
+}
+
+// String returns the rewritten CSS.
+func (s *StyleSheet) String() string { return s.css }
+
+// Scope returns a Mod that scopes class names of the node to the stylesheet.
+func (s *StyleSheet) Scope() Mod { return func(n *Node) { n.Scope(s) } }
+
+// Scope rewrites class names of the node, including classes added later, to the names scoped by s.
+// Just before rendering, the scope is also applied to descendants,
+// except those that have their own scope.
+func (n *Node) Scope(s *StyleSheet) *Node {
+	if s == nil {
+		return n
+	}
+	n.class.setScope(s)
+	n.postponed = append(n.postponed, func(n *Node) {
+		for _, c := range n.content {
+			scopeTree(c, s)
+		}
+	})
+	return n
+}
+
+func scopeTree(n *Node, s *StyleSheet) {
+	if n == nil || n.class.scope != nil || n.writeFn != nil && n.tag != "$group" {
+		return
+	}
+	n.class.setScope(s)
+	for _, c := range n.content {
+		scopeTree(c, s)
+	}
+}
+
+// Styles returns a style element with the CSS of all registered stylesheets.
+func Styles() *Node {
+	b, _ := stylesBundle()
+	return StyleTag().Content(RawBytes(b))
+}
+
+// WriteStyles writes the CSS of all registered stylesheets to w.
+func WriteStyles(w io.Writer) error {
+	b, _ := stylesBundle()
+	_, err := w.Write(b)
+	return err
+}
+
+// StylesHandler returns a handler that serves the CSS of all registered stylesheets as a single bundle.
+func StylesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, etag := stylesBundle()
+		w.Header().Set("Content-Type", "text/css; charset=utf-8")
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write(b)
+	})
+}
+
+func stylesBundle() ([]byte, string) {
+	styleSheets.mu.RLock()
+	b, etag := styleSheets.bundle, styleSheets.etag
+	styleSheets.mu.RUnlock()
+	if b != nil {
+		return b, etag
+	}
+
+	styleSheets.mu.Lock()
+	defer styleSheets.mu.Unlock()
+	if styleSheets.bundle == nil {
+		var buf bytes.Buffer
+		for _, s := range styleSheets.list {
+			buf.WriteString(s.css)
+			buf.WriteByte('\n')
+		}
+		h := fnv.New64a()
+		_, _ = h.Write(buf.Bytes())
+		styleSheets.bundle = buf.Bytes()
+		styleSheets.etag = `"` + strconv.FormatUint(h.Sum64(), 36) + `"`
+	}
+	return styleSheets.bundle, styleSheets.etag
+}
+
+// scopeCSS replaces class names in selectors of src using fn.
+func scopeCSS(src string, fn func(string) string) string {
+	var sb strings.Builder
+	sb.Grow(len(src) + len(src)/8)
+
+	// stack of open blocks, true for blocks that contain declarations
+	var blocks []bool
+	prelude := -1 // start of the current prelude
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		inDecl := len(blocks) > 0 && blocks[len(blocks)-1]
+		switch {
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src)
+			} else {
+				end += i + 4
+			}
+			sb.WriteString(src[i:end])
+			i = end - 1
+			continue
+
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(src) && src[end] != c {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(src))
+			sb.WriteString(src[i:end])
+			i = end - 1
+			continue
+
+		case c == '{':
+			p := ""
+			if prelude >= 0 {
+				p = strings.TrimSpace(src[prelude:i])
+			}
+			decl := true
+			if strings.HasPrefix(p, "@") {
+				decl = !(strings.HasPrefix(p, "@media") || strings.HasPrefix(p, "@supports") ||
+					strings.HasPrefix(p, "@container") || strings.HasPrefix(p, "@layer") ||
+					strings.HasPrefix(p, "@scope") || strings.HasPrefix(p, "@document"))
+			}
+			if inDecl {
+				decl = true
+			}
+			blocks = append(blocks, decl)
+			prelude = -1
+
+		case c == '}':
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+			prelude = -1
+
+		case c == ';':
+			prelude = -1
+
+		case c == '.' && !inDecl && i+1 < len(src) && cssNameStart(src[i+1]) && (prelude < 0 || src[prelude] != '@'):
+			if prelude < 0 {
+				prelude = i
+			}
+			end := i + 1
+			for end < len(src) && cssNameChar(src[end]) {
+				if src[end] == '\\' && end+1 < len(src) {
+					end++
+				}
+				end++
+			}
+			sb.WriteByte('.')
+			sb.WriteString(fn(src[i+1 : end]))
+			i = end - 1
+			continue
+
+		default:
+			if prelude < 0 && c != ' ' && c != '\n' && c != '\t' && c != '\r' && !inDecl {
+				prelude = i
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+func cssNameStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '-' || c >= 0x80
+}
+
+func cssNameChar(c byte) bool {
+	return cssNameStart(c) || c >= '0' && c <= '9' || c == '\\'
+}
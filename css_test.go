@@ -0,0 +1,72 @@
+package htm
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_CSS_ScopesSelectors(t *testing.T) {
+	s := CSS(`/* .comment */ .root, .root > .label:hover { background: url(a.png); width: 1.5em }
+@media (min-width: 10.5em) { .root .sm\:wide { content: ".x" } }
+[data-x=".y"] .label {}`)
+
+	root, label, wide := s.Class("root"), s.Class("label"), s.Class("sm:wide")
+	suffix := strings.TrimPrefix(root, "root")
+	if suffix == "" || label != "label"+suffix || wide != "sm:wide"+suffix || s.Class("other") != "other" {
+		t.Fatalf("unexpected scoped names: %q %q %q", root, label, wide)
+	}
+
+	want := `/* .comment */ .root` + suffix + `, .root` + suffix + ` > .label` + suffix + `:hover { background: url(a.png); width: 1.5em }
+@media (min-width: 10.5em) { .root` + suffix + ` .sm\:wide` + suffix + ` { content: ".x" } }
+[data-x=".y"] .label` + suffix + ` {}`
+	if got := s.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_CSS_ScopeRewritesClasses(t *testing.T) {
+	s := CSS(`.card{padding:1rem}.title{font-weight:bold}`)
+	other := CSS(`.icon{width:1em}`)
+
+	n := Div(Class("card shadow"), s.Scope(), Content(
+		H2().Class("title"),
+		Span().Scope(other).Class("icon title"),
+	)).Class("card")
+	defer n.Release()
+
+	want := `<div class="` + s.Class("card") + ` shadow"><h2 class="` + s.Class("title") + `"></h2>` +
+		`<span class="` + other.Class("icon") + ` title"></span></div>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if !n.HasClass("card") {
+		t.Fatalf("expected HasClass to use scoped names")
+	}
+
+	rec := httptest.NewRecorder()
+	StylesHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/styles.css", nil))
+	if body := rec.Body.String(); !strings.Contains(body, s.String()) || !strings.Contains(body, other.String()) {
+		t.Fatalf("bundle does not contain registered stylesheets: %s", body)
+	}
+	styles := Styles()
+	defer styles.Release()
+	if got := styles.String(); !strings.HasPrefix(got, "<style>") || !strings.Contains(got, s.String()) {
+		t.Fatalf("unexpected style block: %s", got)
+	}
+}
+
+func Test_CSS_Deduplicates(t *testing.T) {
+	src := `.dedup{color:red}`
+	a, b := CSS(src), CSS(src)
+	if a != b {
+		t.Fatalf("expected the registered stylesheet to be returned")
+	}
+	var sb strings.Builder
+	if err := WriteStyles(&sb); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(sb.String(), a.String()); got != 1 {
+		t.Fatalf("unexpected count of the stylesheet in the bundle: %d", got)
+	}
+}
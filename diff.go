@@ -0,0 +1,496 @@
+package htm
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PatchOp is the kind of operation of a Patch.
+type PatchOp string
+
+const (
+	PatchSetAttr     PatchOp = "setAttr"     // set attribute Name to Value
+	PatchRemoveAttr  PatchOp = "removeAttr"  // remove attribute Name
+	PatchAddClass    PatchOp = "addClass"    // add class Name
+	PatchRemoveClass PatchOp = "removeClass" // remove class Name
+	PatchSetText     PatchOp = "setText"     // set the text of a text node to Value
+	PatchReplace     PatchOp = "replace"     // replace the node with HTML
+	PatchInsert      PatchOp = "insert"      // insert HTML as the child at Index
+	PatchRemove      PatchOp = "remove"      // remove the child at Index
+	PatchMove        PatchOp = "move"        // move the child at From to Index, From is always greater
+)
+
+// Patch is an operation that turns a part of a rendered tree into the corresponding part of another tree.
+// Path holds the child indexes leading from the root to the target node;
+// for insert, remove and move it leads to the parent.
+type Patch struct {
+	Op    PatchOp `json:"op"`
+	Path  []int   `json:"path"`
+	Name  string  `json:"name,omitempty"`
+	Value string  `json:"value,omitempty"`
+	HTML  string  `json:"html,omitempty"`
+	Index int     `json:"index,omitempty"`
+	From  int     `json:"from,omitempty"`
+}
+
+// String returns a readable form of the patch, e.g. `setAttr /0/2 href="/next"`.
+func (p Patch) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(p.Op))
+	sb.WriteByte(' ')
+	if len(p.Path) == 0 {
+		sb.WriteByte('/')
+	}
+	for _, i := range p.Path {
+		sb.WriteByte('/')
+		sb.WriteString(strconv.Itoa(i))
+	}
+	switch p.Op {
+	case PatchSetAttr:
+		sb.WriteString(" " + p.Name + "=" + strconv.Quote(p.Value))
+	case PatchRemoveAttr, PatchAddClass, PatchRemoveClass:
+		sb.WriteString(" " + p.Name)
+	case PatchSetText:
+		sb.WriteString(" " + strconv.Quote(p.Value))
+	case PatchReplace:
+		sb.WriteString(" " + p.HTML)
+	case PatchInsert:
+		sb.WriteString(" @" + strconv.Itoa(p.Index) + " " + p.HTML)
+	case PatchRemove:
+		sb.WriteString(" @" + strconv.Itoa(p.Index))
+	case PatchMove:
+		sb.WriteString(" @" + strconv.Itoa(p.From) + " -> @" + strconv.Itoa(p.Index))
+	}
+	return sb.String()
+}
+
+// Diff returns the patches that turn the rendered old tree into the new one.
+//
+// Children are matched by Key; children without a key are matched in order.
+// Nodes that render no element of their own (groups, slot outlets, components, Static nodes
+// and the content of markers) are flattened into their parent, so paths follow the rendered DOM,
+// while other nodes with custom rendering (raw HTML, comments) are compared
+// by their output and replaced as a whole; such nodes are expected to render a single DOM node.
+// Adjacent text nodes are merged by browsers, so trees patched on the client should not have them.
+//
+// Both trees are prepared for rendering the same way as by Render.
+// Diff is also useful in tests, where the patches show structural differences of two trees.
+func Diff(old, new *Node) []Patch {
+	return DiffWith(nil, old, new)
+}
+
+// DiffWith is like Diff, but compares the trees as rendered by r and renders the HTML of the patches with r,
+// so patches match output of r.Render (e.g. with KeyAttr, a locale or EscapeASCII). A nil r is the same as Diff.
+func DiffWith(r *Renderer, old, new *Node) []Patch {
+	d := differ{r: r}
+	defer d.release()
+	d.node(old, new)
+	return d.patches
+}
+
+type differ struct {
+	r       *Renderer
+	patches []Patch
+	path    []int
+	ctx     *Context       // the context attributes are rendered with if r is set
+	temp    []*Node        // nodes created by flatten, released by release
+	derived map[*Node]bool // children that are not part of the diffed trees
+}
+
+func (d *differ) release() {
+	for _, n := range d.temp {
+		n.Release()
+	}
+}
+
+func (d *differ) add(p Patch) {
+	p.Path = append([]int{}, d.path...)
+	d.patches = append(d.patches, p)
+}
+
+type diffKind uint8
+
+const (
+	diffNone diffKind = iota
+	diffElement
+	diffText
+	diffOpaque
+)
+
+func diffKindOf(n *Node) diffKind {
+	switch {
+	case n == nil:
+		return diffNone
+	case n.tag == "$text" || n.tag == "$t":
+		return diffText
+	case n.writeFn != nil:
+		return diffOpaque
+	default:
+		return diffElement
+	}
+}
+
+func (d *differ) node(old, new *Node) {
+	ok, nk := diffKindOf(old), diffKindOf(new)
+	switch {
+	case ok == diffNone && nk == diffNone:
+		return
+	case ok != nk || ok == diffElement && old.tag != new.tag || keyOf(old) != keyOf(new):
+		d.add(Patch{Op: PatchReplace, HTML: d.html(new)})
+	case ok == diffText:
+		if o, n := d.html(old), d.html(new); o != n {
+			d.add(Patch{Op: PatchSetText, Value: html.UnescapeString(n)})
+		}
+	case ok == diffOpaque:
+		if o, n := d.html(old), d.html(new); o != n {
+			d.add(Patch{Op: PatchReplace, HTML: n})
+		}
+	default:
+		prepareDiff(old)
+		prepareDiff(new)
+		d.attrs(old, new)
+		d.classes(old, new)
+		d.children(old, new)
+	}
+}
+
+func prepareDiff(n *Node) {
+	for _, fn := range n.postponed {
+		fn(n)
+	}
+	if len(n.slots) > 0 {
+		_ = n.fillOutlets(false)
+	}
+}
+
+func keyOf(n *Node) string {
+	if n == nil {
+		return ""
+	}
+	if k := n.GetKey(); k.Valid() {
+		// the type is part of the key, so 1 and "1" don't match
+		v := k.Any()
+		return fmt.Sprintf("%T:%v", v, v)
+	}
+	return ""
+}
+
+func (d *differ) attrs(old, new *Node) {
+	om, nm := d.renderedAttrs(old), d.renderedAttrs(new)
+	for _, a := range om {
+		if _, ok := findAttr(nm, a.name); !ok {
+			d.add(Patch{Op: PatchRemoveAttr, Name: a.name})
+		}
+	}
+	for _, a := range nm {
+		if v, ok := findAttr(om, a.name); !ok || v != a.value {
+			d.add(Patch{Op: PatchSetAttr, Name: a.name, Value: a.value})
+		}
+	}
+}
+
+type renderedAttr struct {
+	name, value string
+}
+
+func findAttr(attrs []renderedAttr, name string) (string, bool) {
+	for _, a := range attrs {
+		if a.name == name {
+			return a.value, true
+		}
+	}
+	return "", false
+}
+
+// html returns the output of n.
+func (d *differ) html(n *Node) string {
+	if d.r == nil {
+		return n.String()
+	}
+	var sb strings.Builder
+	_ = d.r.Render(&sb, n)
+	return sb.String()
+}
+
+// renderedAttrs returns unescaped attribute values as they are rendered, including style and the key.
+func (d *differ) renderedAttrs(n *Node) []renderedAttr {
+	var res []renderedAttr
+	var sb strings.Builder
+	var w io.Writer = &sb
+	if d.r != nil {
+		if d.ctx == nil {
+			d.ctx = &Context{Renderer: d.r}
+			if d.r.Context != nil {
+				*d.ctx = *d.r.Context
+				d.ctx.Renderer = d.r
+			}
+		}
+		w = WithContext(&sb, d.ctx)
+	}
+	add := func() {
+		if sb.Len() == 0 {
+			return
+		}
+		s := sb.String()[1:] // leading space
+		name, value, _ := strings.Cut(s, "=")
+		res = append(res, renderedAttr{name, html.UnescapeString(strings.Trim(value, `"`))})
+	}
+	for _, a := range n.attrs.o {
+		sb.Reset()
+		if writeAttributes(w, []valueEntry{a}) == nil {
+			add()
+		}
+	}
+	if d.r != nil && d.r.KeyAttr != "" {
+		sb.Reset()
+		if n.writeKey(w, d.r.KeyAttr) == nil {
+			add()
+		}
+	}
+	if v := n.styleAttr(); v.Valid() {
+		res = append(res, renderedAttr{"style", v.StringOrZero()})
+	}
+	return res
+}
+
+func (d *differ) classes(old, new *Node) {
+	var merge ClassResolver
+	if d.r != nil {
+		merge = d.r.ClassMerge
+	}
+	oc, nc := renderedClasses(old.class, merge), renderedClasses(new.class, merge)
+	for _, c := range oc {
+		if !slices.Contains(nc, c) {
+			d.add(Patch{Op: PatchRemoveClass, Name: c})
+		}
+	}
+	for _, c := range nc {
+		if !slices.Contains(oc, c) {
+			d.add(Patch{Op: PatchAddClass, Name: c})
+		}
+	}
+}
+
+// renderedClasses returns the final (scoped) names of the rendered classes.
+func renderedClasses(cm *classMap, merge ClassResolver) []string {
+	var groups []classGroup
+	if merge != nil {
+		groups = classGroups(cm.o, merge, nil)
+	}
+	var res []string
+	for i, c := range cm.o {
+		if c.active && ValidClass(c.name) && (groups == nil || !overridden(cm.o, groups, i)) {
+			res = append(res, c.name)
+		}
+	}
+	return res
+}
+
+func (d *differ) children(old, new *Node) {
+	oc, nc := d.flatten(old.content, nil), d.flatten(new.content, nil)
+	ok, nk := childKeys(oc), childKeys(nc)
+
+	wanted := make(map[string]bool, len(nk))
+	for _, k := range nk {
+		wanted[k] = true
+	}
+	// remove from the end, so indexes of the preceding children stay valid
+	for i := len(oc) - 1; i >= 0; i-- {
+		if !wanted[ok[i]] {
+			d.add(Patch{Op: PatchRemove, Index: i})
+			oc = append(oc[:i], oc[i+1:]...)
+			ok = append(ok[:i], ok[i+1:]...)
+		}
+	}
+
+	for i, k := range nk {
+		j := i
+		for j < len(ok) && ok[j] != k {
+			j++
+		}
+		if j == len(ok) {
+			d.add(Patch{Op: PatchInsert, Index: i, HTML: d.html(nc[i])})
+			oc = append(oc[:i], append([]*Node{nc[i]}, oc[i:]...)...)
+			ok = append(ok[:i], append([]string{k}, ok[i:]...)...)
+			continue
+		}
+		if j != i {
+			d.add(Patch{Op: PatchMove, From: j, Index: i})
+			c := oc[j]
+			copy(oc[i+1:j+1], oc[i:j])
+			copy(ok[i+1:j+1], ok[i:j])
+			oc[i], ok[i] = c, k
+		}
+		d.path = append(d.path, i)
+		d.node(oc[i], nc[i])
+		d.path = d.path[:len(d.path)-1]
+	}
+}
+
+// flatten returns the nodes that render the DOM children of content: nil nodes are dropped,
+// nodes that render no element of their own are replaced by their content,
+// Static nodes by the tree they render and markers with content by the marker comments around it.
+func (d *differ) flatten(content []*Node, res []*Node) []*Node {
+	for _, c := range content {
+		switch {
+		case c == nil:
+		case c.tag == "$group" || c.tag == "$outlet":
+			res = d.flatten(c.content, res)
+		case c.tag == "$component":
+			if st, ok := c.value.any.(interface{ isBuilt() bool }); ok && !st.isBuilt() {
+				// the content of a component is known once it is built by its first render
+				if c.Render(io.Discard) != nil {
+					res = append(res, c)
+					continue
+				}
+			}
+			prepareDiff(c)
+			res = d.flatten(c.content, res)
+		case c.tag == "$static":
+			res = d.derive(res, c.value.any.(func() *Node)())
+		case c.tag == "$marker" && len(c.content) > 0:
+			name := c.value.StringOrZero()
+			res = d.derive(res, Marker(name))
+			res = d.flatten(c.content, res)
+			res = d.derive(res, Marker("/"+name))
+		default:
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// derive appends the flattened n, which is not part of the diffed trees, and releases it with d.
+func (d *differ) derive(res []*Node, n *Node) []*Node {
+	d.temp = append(d.temp, n)
+	if d.derived == nil {
+		d.derived = make(map[*Node]bool)
+	}
+	start := len(res)
+	res = d.flatten([]*Node{n}, res)
+	for _, c := range res[start:] {
+		d.derived[c] = true
+	}
+	return res
+}
+
+// childKeys returns the keys of the nodes; nodes without a key get one by their order.
+// Repeated keys are numbered by their occurrence, so each child is matched at most once.
+func childKeys(nodes []*Node) []string {
+	keys := make([]string, len(nodes))
+	var seen map[string]int
+	seq := 0
+	for i, n := range nodes {
+		k := keyOf(n)
+		if k == "" {
+			keys[i] = "#" + strconv.Itoa(seq)
+			seq++
+			continue
+		}
+		keys[i] = "k:" + k
+		if seen == nil {
+			seen = make(map[string]int)
+		}
+		if c := seen[k]; c > 0 {
+			keys[i] += "#" + strconv.Itoa(c)
+		}
+		seen[k]++
+	}
+	return keys
+}
+
+// DiffFragments returns the nodes of the new tree that have an id attribute and together
+// cover all differences from the old tree, for out-of-band swaps (e.g. hx-swap-oob or the htmx ws extension).
+// Changes outside of nodes with an id are covered by the root; nil is returned if there are no changes.
+func DiffFragments(old, new *Node) []*Node {
+	return DiffFragmentsWith(nil, old, new)
+}
+
+// DiffFragmentsWith is like DiffFragments, but compares the trees as rendered by r, see DiffWith.
+func DiffFragmentsWith(r *Renderer, old, new *Node) []*Node {
+	var d differ
+	defer d.release()
+	var res []*Node
+	var paths [][]int
+	for _, p := range DiffWith(r, old, new) {
+		path := p.Path
+		if p.Op == PatchReplace || p.Op == PatchSetAttr && p.Name == "id" || p.Op == PatchRemoveAttr && p.Name == "id" {
+			// the id of the target itself may change, so the parent is swapped
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+		n, depth := new, 0
+		for i, idx := range path {
+			children := d.flatten(n.content, nil)
+			if idx >= len(children) || d.derived[children[idx]] {
+				break // swapping nodes rendered by Static and markers means swapping their parent
+			}
+			if n = children[idx]; n.GetAttr("id").Valid() {
+				depth = i + 1
+			}
+		}
+		path = path[:depth]
+		if covered(paths, path) {
+			continue
+		}
+		// drop fragments inside the new one
+		j := 0
+		for i := range paths {
+			if !hasPrefix(paths[i], path) {
+				paths[j], res[j] = paths[i], res[i]
+				j++
+			}
+		}
+		paths, res = append(paths[:j], path), append(res[:j], d.nodeAt(new, path))
+	}
+	return res
+}
+
+func covered(paths [][]int, path []int) bool {
+	for _, p := range paths {
+		if hasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefix(path, prefix []int) bool {
+	return len(path) >= len(prefix) && slices.Equal(path[:len(prefix)], prefix)
+}
+
+func (d *differ) nodeAt(n *Node, path []int) *Node {
+	for _, idx := range path {
+		n = d.flatten(n.content, nil)[idx]
+	}
+	return n
+}
+
+// PatchScript is a small client script that defines htmPatch(root, patches),
+// applying patches produced by Diff to the DOM element rendered from the old tree.
+// It returns the root element, which is a new one if the root was replaced.
+const PatchScript = `function htmPatch(root, patches) {
+	for (const p of patches) {
+		let n = root;
+		for (const i of p.path) n = n.childNodes[i];
+		const t = document.createElement("template");
+		switch (p.op) {
+		case "setAttr": n.setAttribute(p.name, p.value || ""); break;
+		case "removeAttr": n.removeAttribute(p.name); break;
+		case "addClass": n.classList.add(p.name); break;
+		case "removeClass": n.classList.remove(p.name); break;
+		case "setText": n.textContent = p.value || ""; break;
+		case "replace": t.innerHTML = p.html; if (n === root) root = t.content.firstElementChild; n.replaceWith(t.content); break;
+		case "insert": t.innerHTML = p.html; n.insertBefore(t.content, n.childNodes[p.index || 0] || null); break;
+		case "remove": n.childNodes[p.index || 0].remove(); break;
+		case "move": n.insertBefore(n.childNodes[p.from || 0], n.childNodes[p.index || 0]); break;
+		}
+	}
+	return root;
+}
+`
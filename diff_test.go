@@ -0,0 +1,147 @@
+package htm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_Diff_AttrsClassesTextAndKeyedChildren(t *testing.T) {
+	item := func(key, text string) *Node { return Li().Key(key).Text(text) }
+
+	old := Div().Class("box open").Attr("title", "a").Attr("hidden").Content(
+		P().Text("hello"),
+		Ul().Content(item("a", "A"), item("b", "B"), item("c", "C"), item("d", "D")),
+		Span(),
+	)
+	defer old.Release()
+
+	new := Div().Class("box closed").Attr("title", "b & c").Style("color: red").Content(
+		P().Text("hello, world"),
+		Ul().Content(item("c", "C"), item("a", "A2"), item("e", "E"), item("b", "B")),
+		Group(Em()),
+	)
+	defer new.Release()
+
+	var got []string
+	for _, p := range Diff(old, new) {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`removeAttr / hidden`,
+		`setAttr / title="b & c"`,
+		`setAttr / style="color:red"`,
+		`removeClass / open`,
+		`addClass / closed`,
+		`setText /0/0 "hello, world"`,
+		`remove /1 @3`,
+		`move /1 @2 -> @0`,
+		`setText /1/1/0 "A2"`,
+		`insert /1 @2 <li>E</li>`,
+		`replace /2 <em></em>`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if len(Diff(old, old)) != 0 {
+		t.Fatalf("expected no patches for the same tree")
+	}
+
+	b, err := json.Marshal(Diff(Span().Text("a"), Span().Text("b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); s != `[{"op":"setText","path":[0],"value":"b"}]` {
+		t.Fatalf("unexpected json: %s", s)
+	}
+}
+
+func Test_DiffFragments(t *testing.T) {
+	page := func(count, total string) *Node {
+		return Main().ID("page").Content(
+			Section().ID("stats").Content(P().Text(count), Div().Content(Span().ID("total").Text(total))),
+			Footer().Text("f"),
+		)
+	}
+	a, b, c := page("1", "10"), page("2", "10"), page("2", "11")
+	defer a.Release()
+	defer b.Release()
+	defer c.Release()
+
+	var got []string
+	for _, n := range DiffFragments(b, c) {
+		got = append(got, n.String())
+	}
+	if want := `<span id="total">11</span>`; strings.Join(got, "") != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", strings.Join(got, ""), want)
+	}
+
+	// a change outside of #total is covered by #stats
+	got = got[:0]
+	for _, n := range DiffFragments(a, c) {
+		got = append(got, n.String())
+	}
+	if want := `<section id="stats"><p>2</p><div><span id="total">11</span></div></section>`; strings.Join(got, "") != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", strings.Join(got, ""), want)
+	}
+	if DiffFragments(c, c) != nil {
+		t.Fatalf("expected no fragments")
+	}
+}
+
+var testCard = Component(func(title string, n *Node) *Node {
+	return Group(H2().Text(title), SlotOutlet("body"))
+})
+
+func testDiffStatic() *Node { return Hr() }
+
+func Test_Diff_FlattensOutletsComponentsMarkersAndStatic(t *testing.T) {
+	page := func(text string) *Node {
+		return Div().Content(
+			testCard.New(testCard.Props("t"), Slot("body", P().Text("a"), P().Text(text))),
+			Marked("m", Span().Text(text)),
+			Static(testDiffStatic),
+			Em().Text(text),
+		)
+	}
+	old, new := page("b"), page("c")
+	defer old.Release()
+	defer new.Release()
+
+	var got []string
+	for _, p := range Diff(old, new) {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`setText /2/0 "c"`,
+		`setText /4/0 "c"`,
+		`setText /7/0 "c"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if got, want := new.String(), `<div><h2>t</h2><p>a</p><p>c</p><!--m--><span>c</span><!--/m--><hr/><em>c</em></div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_DiffWith_Renderer(t *testing.T) {
+	old := Ul().Content(Li().Key("a").T("Save"))
+	defer old.Release()
+	new := Ul().Content(Li().Key("a").T("Save"), Li().Key("b").T("Save"))
+	defer new.Release()
+
+	r := &Renderer{
+		KeyAttr: "data-key",
+		Context: &Context{Locale: NewLocale("de", MapCatalog{"de": {"Save": {Other: "Speichern"}}})},
+	}
+	var got []string
+	for _, p := range DiffWith(r, old, new) {
+		got = append(got, p.String())
+	}
+	if want := `insert / @1 <li data-key="b">Speichern</li>`; strings.Join(got, "\n") != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", strings.Join(got, "\n"), want)
+	}
+
+}
@@ -0,0 +1,77 @@
+package htm
+
+import (
+	"io"
+	"slices"
+)
+
+// Key returns a Mod that sets the key of the node. See Node.Key.
+func Key(key any) Mod { return func(n *Node) { n.Key(key) } }
+
+// Key sets the key that identifies the node among its siblings across renders,
+// e.g. the ID of the record a list item is rendered from.
+// Diff matches children by their keys, so reordered children are moved instead of being replaced.
+// Keys match only if their types match too: Key(1) and Key("1") are different keys.
+// The key is not rendered unless Renderer.KeyAttr is set.
+func (n *Node) Key(key any) *Node { return n.VarValue("htm_key", keyValue(key)) }
+
+// GetKey returns the key of the node, or Unset.
+func (n *Node) GetKey() TypedValue { return n.GetVar("htm_key") }
+
+func keyValue(key any) TypedValue {
+	switch k := key.(type) {
+	case nil:
+		return Unset
+	case TypedValue:
+		return k
+	case string:
+		return String(k)
+	case int:
+		return Int(k)
+	case int64:
+		return Int64(k)
+	case int32:
+		return Int64(int64(k))
+	case uint:
+		return Uint(k)
+	case uint64:
+		return Uint64(k)
+	case uint32:
+		return Uint64(uint64(k))
+	default:
+		return Any(k)
+	}
+}
+
+// Each renders items into a group of keyed nodes.
+// For every item, render builds the node and key returns its key;
+// key may be nil for lists without stable identity. Nil nodes are skipped.
+//
+//	htm.Ul().Content(htm.Each(todos,
+//		func(t Todo) any { return t.ID },
+//		func(t Todo) *htm.Node { return htm.Li().Text(t.Title) },
+//	))
+func Each[T any](items []T, key func(T) any, render func(T) *Node) *Node {
+	g := Group()
+	g.content = slices.Grow(g.content, len(items))
+	for _, item := range items {
+		n := render(item)
+		if n == nil {
+			continue
+		}
+		if key != nil {
+			n.Key(key(item))
+		}
+		g.content = append(g.content, n)
+	}
+	return g
+}
+
+// writeKey writes the key of the node as the attribute name, unless the node has it set.
+func (n *Node) writeKey(w io.Writer, name string) error {
+	k := n.GetKey()
+	if !k.Valid() || n.GetAttr(name).Valid() {
+		return nil
+	}
+	return writeAttributes(w, []valueEntry{{name: name, value: k}})
+}
@@ -0,0 +1,75 @@
+package htm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_Each_KeyedChildren(t *testing.T) {
+	type todo struct {
+		ID    int
+		Title string
+	}
+	list := func(todos ...todo) *Node {
+		return Ul().Content(Each(todos,
+			func(t todo) any { return t.ID },
+			func(t todo) *Node { return If(t.Title != "", func() *Node { return Li().Text(t.Title) }) },
+		))
+	}
+
+	a := list(todo{1, "one"}, todo{2, "two"}, todo{3, ""})
+	defer a.Release()
+	if got, want := a.String(), `<ul><li>one</li><li>two</li></ul>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := (&Renderer{KeyAttr: "data-key"}).Render(&buf, a); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `<ul><li data-key="1">one</li><li data-key="2">two</li></ul>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	b := list(todo{2, "two"}, todo{1, "one"})
+	defer b.Release()
+	patches := Diff(a, b)
+	if len(patches) != 1 || patches[0].String() != "move / @1 -> @0" {
+		t.Fatalf("unexpected patches: %v", patches)
+	}
+}
+
+func Test_Each_KeyTypes(t *testing.T) {
+	a := Ul().Content(Li().Key(1).Text("a"), Li().Key("2").Text("b"))
+	defer a.Release()
+	b := Ul().Content(Li().Key("1").Text("a"), Li().Key("2").Text("b"))
+	defer b.Release()
+
+	patches := Diff(a, b)
+	if len(patches) != 2 || patches[0].String() != "remove / @0" || patches[1].String() != "insert / @0 <li>a</li>" {
+		t.Fatalf("unexpected patches: %v", patches)
+	}
+
+	c := Ul().Content(Li().Key(int64(1)).Text("a"), Li().Key("2").Text("b"))
+	defer c.Release()
+	if patches := Diff(a, c); len(patches) != 0 {
+		t.Fatalf("unexpected patches: %v", patches)
+	}
+}
+
+func Test_Each_DuplicateKeys(t *testing.T) {
+	a := Ul().Content(Li().Key("a").Text("1"), Li().Key("a").Text("2"), Li().Key("b").Text("3"))
+	defer a.Release()
+	b := Ul().Content(Li().Key("b").Text("3"), Li().Key("a").Text("1"))
+	defer b.Release()
+
+	var got []string
+	for _, p := range Diff(a, b) {
+		got = append(got, p.String())
+	}
+	want := "remove / @1\nmove / @1 -> @0"
+	if s := strings.Join(got, "\n"); s != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", s, want)
+	}
+}
@@ -0,0 +1,321 @@
+package htm
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts used to decode time.Time form fields.
+// They match the formats produced by MinDate/MaxDate and expected by date inputs.
+const (
+	FormDateLayout     = "2006-01-02"
+	FormDateTimeLayout = "2006-01-02T15:04"
+	FormTimeLayout     = "15:04"
+)
+
+// FormErrors holds per-field decoding errors keyed by form field name.
+type FormErrors map[string]string
+
+// Error implements the error interface.
+func (fe FormErrors) Error() string {
+	names := make([]string, 0, len(fe))
+	for name := range fe {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(name)
+		b.WriteString(": ")
+		b.WriteString(fe[name])
+	}
+	return b.String()
+}
+
+// Get returns the error message for the named field, or an empty string.
+func (fe FormErrors) Get(name string) string { return fe[name] }
+
+// Has checks if there is an error for the named field.
+func (fe FormErrors) Has(name string) bool {
+	_, ok := fe[name]
+	return ok
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// DecodeForm populates the struct pointed to by dst from submitted form values (e.g. r.PostForm).
+//
+// Field names are taken from the "form" struct tag, falling back to the Go field name.
+// A tag value of "-" skips the field. Tag options follow the name, separated by commas:
+//
+//	required  - the field must be present and non-empty
+//	date      - time.Time is parsed using FormDateLayout (default)
+//	datetime  - time.Time is parsed using FormDateTimeLayout (datetime-local inputs)
+//	time      - time.Time is parsed using FormTimeLayout
+//
+// Booleans are decoded as checkboxes: an absent field is false, a present one is true
+// unless its value is "false", "off" or "0". Slices collect all submitted values,
+// as sent by multiple selects. Nested structs use "parent.child" names;
+// embedded structs are flattened.
+//
+// If some fields cannot be decoded, the remaining fields are still populated and
+// the returned error is FormErrors.
+func DecodeForm(values url.Values, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("htm: DecodeForm expects a non-nil pointer to a struct, got %T", dst)
+	}
+	fe := make(FormErrors)
+	decodeFormStruct(values, rv.Elem(), "", fe)
+	if len(fe) > 0 {
+		return fe
+	}
+	return nil
+}
+
+func decodeFormStruct(values url.Values, sv reflect.Value, prefix string, fe FormErrors) {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := sv.Field(i)
+
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			decodeFormStruct(values, fv, prefix, fe)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		name = prefix + name
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != timeType && !reflect.PointerTo(sf.Type).Implements(textUnmarshalerType) {
+			decodeFormStruct(values, fv, name+".", fe)
+			continue
+		}
+		if err := decodeFormField(values, name, opts, fv); err != nil {
+			fe[name] = err.Error()
+		}
+	}
+}
+
+var errFormRequired = errors.New("is required")
+
+func decodeFormField(values url.Values, name, opts string, fv reflect.Value) error {
+	vals, present := values[name]
+	required := hasFormOpt(opts, "required")
+
+	if fv.Kind() == reflect.Bool {
+		v := present && len(vals) > 0
+		if v {
+			switch strings.ToLower(vals[0]) {
+			case "false", "off", "0":
+				v = false
+			}
+		}
+		if required && !v {
+			return errFormRequired
+		}
+		fv.SetBool(v)
+		return nil
+	}
+
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		n := 0
+		for _, s := range vals {
+			if s != "" {
+				n++
+			}
+		}
+		if n == 0 {
+			if required {
+				return errFormRequired
+			}
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+		out := reflect.MakeSlice(fv.Type(), 0, n)
+		for _, s := range vals {
+			if s == "" {
+				continue
+			}
+			ev := reflect.New(fv.Type().Elem()).Elem()
+			if err := decodeFormScalar(s, opts, ev); err != nil {
+				return err
+			}
+			out = reflect.Append(out, ev)
+		}
+		fv.Set(out)
+		return nil
+	}
+
+	var s string
+	if len(vals) > 0 {
+		s = strings.TrimSpace(vals[0])
+	}
+	if s == "" {
+		if required {
+			return errFormRequired
+		}
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+	return decodeFormScalar(s, opts, fv)
+}
+
+func decodeFormScalar(s, opts string, fv reflect.Value) error {
+	if fv.Kind() == reflect.Pointer {
+		pv := reflect.New(fv.Type().Elem())
+		if err := decodeFormScalar(s, opts, pv.Elem()); err != nil {
+			return err
+		}
+		fv.Set(pv)
+		return nil
+	}
+	if fv.CanAddr() && fv.Addr().Type().Implements(textUnmarshalerType) && fv.Type() != timeType {
+		if err := fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return fmt.Errorf("is invalid: %w", err)
+		}
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+
+	case reflect.Bool:
+		switch strings.ToLower(s) {
+		case "false", "off", "0":
+			fv.SetBool(false)
+		default:
+			fv.SetBool(true)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fv.Type() == durationType {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return errors.New("must be a duration")
+			}
+			fv.SetInt(int64(d))
+			return nil
+		}
+		v, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		fv.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return errors.New("must be a non-negative integer")
+		}
+		fv.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		fv.SetFloat(v)
+
+	case reflect.Struct:
+		if fv.Type() != timeType {
+			return fmt.Errorf("unsupported type %v", fv.Type())
+		}
+		layout := FormDateLayout
+		switch {
+		case hasFormOpt(opts, "datetime"):
+			layout = FormDateTimeLayout
+		case hasFormOpt(opts, "time"):
+			layout = FormTimeLayout
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return errors.New("must be a valid date")
+		}
+		fv.Set(reflect.ValueOf(t))
+
+	default:
+		return fmt.Errorf("unsupported type %v", fv.Type())
+	}
+	return nil
+}
+
+func hasFormOpt(opts, opt string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if strings.TrimSpace(o) == opt {
+			return true
+		}
+	}
+	return false
+}
+
+/**/
+
+var (
+	// FieldErrorClass is added to nodes marked with FieldError.
+	FieldErrorClass = "htm-invalid"
+	// FieldErrorMessageClass is added to the element holding the error message.
+	FieldErrorMessageClass = "htm-field-error"
+	// FieldErrorSlot is the name of the slot that receives the error message element.
+	FieldErrorSlot = "error"
+)
+
+// FieldError returns a Mod that marks the node as invalid. See Node.FieldError.
+func FieldError(msg string) Mod { return func(n *Node) { n.FieldError(msg) } }
+
+// FieldError marks the node as invalid and attaches an error message to it.
+// It sets aria-invalid, adds FieldErrorClass, and places a message element with
+// an id assigned at render time into the FieldErrorSlot slot; aria-describedby is pointed at that id.
+// The surrounding component is responsible for rendering the slot.
+// An empty message is ignored.
+func (n *Node) FieldError(msg string) *Node {
+	if msg == "" {
+		return n
+	}
+	key := ""
+	if name := n.GetAttr("name").StringOrZero(); name != "" {
+		key = name + "-error"
+	}
+	id := NewID(key).Value()
+	n.Attr("aria-invalid", "true")
+	if ids := n.GetAttr("aria-describedby"); ids.Valid() {
+		n.AttrValue("aria-describedby", IDList(ids, id))
+	} else {
+		n.AttrValue("aria-describedby", id)
+	}
+	n.Class(FieldErrorClass)
+	return n.Slot(FieldErrorSlot, Span().AttrValue("id", id).Class(FieldErrorMessageClass).Text(msg))
+}
+
+// Mod returns a FieldError Mod for the named field, or nil if there is no error for it.
+func (fe FormErrors) Mod(name string) Mod {
+	if msg, ok := fe[name]; ok {
+		return FieldError(msg)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package htm
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func Test_DecodeForm_FieldsCheckboxesSelectsDates(t *testing.T) {
+	type Address struct {
+		City string `form:"city"`
+	}
+	type Profile struct {
+		Name      string    `form:"name,required"`
+		Age       int       `form:"age"`
+		Score     *float64  `form:"score"`
+		Subscribe bool      `form:"subscribe"`
+		Archived  bool      `form:"archived"`
+		Tags      []string  `form:"tags"`
+		IDs       []int     `form:"ids"`
+		Birthday  time.Time `form:"birthday"`
+		Address   Address   `form:"address"`
+		Ignored   string    `form:"-"`
+	}
+
+	values := url.Values{
+		"name":         {"Alice"},
+		"age":          {"42"},
+		"score":        {"1.5"},
+		"subscribe":    {"on"},
+		"tags":         {"a", "", "b"},
+		"ids":          {"1", "2"},
+		"birthday":     {"1990-05-17"},
+		"address.city": {"Paris"},
+		"Ignored":      {"x"},
+	}
+
+	p := Profile{Archived: true}
+	if err := DecodeForm(values, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name != "Alice" || p.Age != 42 || p.Score == nil || *p.Score != 1.5 {
+		t.Fatalf("unexpected scalars: %+v", p)
+	}
+	if !p.Subscribe || p.Archived {
+		t.Fatalf("unexpected checkboxes: subscribe=%v archived=%v", p.Subscribe, p.Archived)
+	}
+	if len(p.Tags) != 2 || p.Tags[1] != "b" || len(p.IDs) != 2 || p.IDs[1] != 2 {
+		t.Fatalf("unexpected multi values: %v %v", p.Tags, p.IDs)
+	}
+	if !p.Birthday.Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date: %v", p.Birthday)
+	}
+	if p.Address.City != "Paris" || p.Ignored != "" {
+		t.Fatalf("unexpected nested/ignored: %+v", p)
+	}
+}
+
+func Test_DecodeForm_ReturnsFieldErrors(t *testing.T) {
+	var v struct {
+		Name  string    `form:"name,required"`
+		Age   int       `form:"age"`
+		When  time.Time `form:"when"`
+		Title string    `form:"title"`
+	}
+	err := DecodeForm(url.Values{"age": {"x"}, "when": {"17/05/1990"}, "title": {"ok"}}, &v)
+
+	var fe FormErrors
+	if !errors.As(err, &fe) {
+		t.Fatalf("expected FormErrors, got %v", err)
+	}
+	if len(fe) != 3 || !fe.Has("name") || !fe.Has("age") || !fe.Has("when") {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	if v.Title != "ok" {
+		t.Fatalf("expected valid fields to be decoded, got %q", v.Title)
+	}
+	if fe.Error() != "age: must be an integer; name: is required; when: must be a valid date" {
+		t.Fatalf("unexpected message: %q", fe.Error())
+	}
+}
+
+func Test_FieldError_SetsAriaClassAndSlot(t *testing.T) {
+	fe := FormErrors{"email": "is required"}
+
+	field := Div()
+	defer field.Release()
+
+	input := Input().Name("email").Attr("aria-describedby", "hint").Mod(fe.Mod("email"), fe.Mod("name"))
+	field.Append(input).Append(input.ExtractSlot(FieldErrorSlot)...)
+
+	want := `<div><input class="htm-invalid" name="email" aria-describedby="hint id-email-error" aria-invalid="true"/>` +
+		`<span class="htm-field-error" id="id-email-error">is required</span></div>`
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		if err := new(Renderer).Render(&buf, field); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("unexpected render:\n got: %s\nwant: %s", got, want)
+		}
+	}
+}
@@ -0,0 +1,438 @@
+package htm
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// Currency describes a currency used by Money values.
+type Currency struct {
+	// Code is the ISO 4217 currency code.
+	Code string
+	// Symbol is used by locale-aware formatting; Code is used if it is empty.
+	Symbol string
+	// Digits is the number of minor unit digits.
+	Digits int
+}
+
+// Common currencies.
+var (
+	USD = &Currency{Code: "USD", Symbol: "$", Digits: 2}
+	EUR = &Currency{Code: "EUR", Symbol: "€", Digits: 2}
+	GBP = &Currency{Code: "GBP", Symbol: "£", Digits: 2}
+	JPY = &Currency{Code: "JPY", Symbol: "¥", Digits: 0}
+	CNY = &Currency{Code: "CNY", Symbol: "¥", Digits: 2}
+	CHF = &Currency{Code: "CHF", Symbol: "CHF", Digits: 2}
+	INR = &Currency{Code: "INR", Symbol: "₹", Digits: 2}
+	PLN = &Currency{Code: "PLN", Symbol: "zł", Digits: 2}
+	UAH = &Currency{Code: "UAH", Symbol: "₴", Digits: 2}
+	RUB = &Currency{Code: "RUB", Symbol: "₽", Digits: 2}
+)
+
+// localeFormat holds number and date conventions of a language or region.
+type localeFormat struct {
+	decimal, group  string
+	groupMin        int
+	date, dateTime  string
+	currencyPattern string
+}
+
+const (
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+)
+
+// localeFormats is keyed by full tag first, then by base language.
+var localeFormats = map[string]localeFormat{
+	"en":    {".", ",", 1, "01/02/2006", "01/02/2006 3:04 PM", "¤#"},
+	"en-GB": {".", ",", 1, "02/01/2006", "02/01/2006 15:04", "¤#"},
+	"en-AU": {".", ",", 1, "02/01/2006", "02/01/2006 3:04 pm", "¤#"},
+	"en-IN": {".", ",", 1, "02/01/2006", "02/01/2006 3:04 pm", "¤#"},
+	"de":    {",", ".", 1, "02.01.2006", "02.01.2006 15:04", "# ¤"},
+	"de-CH": {".", "’", 1, "02.01.2006", "02.01.2006 15:04", "¤ #"},
+	"fr":    {",", narrowNbsp, 1, "02/01/2006", "02/01/2006 15:04", "# ¤"},
+	"fr-CH": {",", narrowNbsp, 1, "02.01.2006", "02.01.2006 15:04", "# ¤"},
+	"es":    {",", ".", 2, "02/01/2006", "02/01/2006 15:04", "# ¤"},
+	"it":    {",", ".", 1, "02/01/2006", "02/01/2006 15:04", "# ¤"},
+	"pt":    {",", ".", 1, "02/01/2006", "02/01/2006 15:04", "¤ #"},
+	"pt-PT": {",", nbsp, 2, "02/01/2006", "02/01/2006 15:04", "# ¤"},
+	"nl":    {",", ".", 1, "02-01-2006", "02-01-2006 15:04", "¤ #"},
+	"sv":    {",", nbsp, 1, "2006-01-02", "2006-01-02 15:04", "# ¤"},
+	"da":    {",", ".", 1, "02.01.2006", "02.01.2006 15.04", "# ¤"},
+	"nb":    {",", nbsp, 1, "02.01.2006", "02.01.2006, 15:04", "# ¤"},
+	"fi":    {",", nbsp, 1, "2.1.2006", "2.1.2006 15.04", "# ¤"},
+	"pl":    {",", nbsp, 2, "02.01.2006", "02.01.2006, 15:04", "# ¤"},
+	"cs":    {",", nbsp, 1, "2. 1. 2006", "2. 1. 2006 15:04", "# ¤"},
+	"sk":    {",", nbsp, 1, "2. 1. 2006", "2. 1. 2006 15:04", "# ¤"},
+	"ru":    {",", nbsp, 1, "02.01.2006", "02.01.2006, 15:04", "# ¤"},
+	"uk":    {",", nbsp, 1, "02.01.2006", "02.01.2006, 15:04", "# ¤"},
+	"be":    {",", nbsp, 1, "02.01.2006", "02.01.2006, 15:04", "# ¤"},
+	"tr":    {",", ".", 1, "02.01.2006", "02.01.2006 15:04", "¤#"},
+	"el":    {",", ".", 1, "2/1/2006", "2/1/2006, 3:04 PM", "# ¤"},
+	"he":    {".", ",", 1, "2.1.2006", "2.1.2006, 15:04", "# ¤"},
+	"ar":    {".", ",", 1, "2/1/2006", "2/1/2006, 3:04 PM", "# ¤"},
+	"hi":    {".", ",", 1, "2/1/2006", "2/1/2006, 3:04 pm", "¤#"},
+	"ja":    {".", ",", 1, "2006/01/02", "2006/01/02 15:04", "¤#"},
+	"zh":    {".", ",", 1, "2006/1/2", "2006/1/2 15:04", "¤#"},
+	"ko":    {".", ",", 1, "2006. 1. 2.", "2006. 1. 2. PM 3:04", "¤#"},
+}
+
+func (l *Locale) applyFormat() {
+	f, ok := localeFormats[l.Tag]
+	if !ok {
+		if f, ok = localeFormats[l.Lang]; !ok {
+			f = localeFormat{".", ",", 1, "2006-01-02", "2006-01-02 15:04", "¤ #"}
+		}
+	}
+	l.Decimal = f.decimal
+	l.Group = f.group
+	l.GroupMin = f.groupMin
+	l.DateLayout = f.date
+	l.DateTimeLayout = f.dateTime
+	l.CurrencyPattern = f.currencyPattern
+}
+
+// WriteInt writes n using the grouping separator of the locale.
+func (l *Locale) WriteInt(w io.Writer, n int64) error {
+	var buf [24]byte
+	b := strconv.AppendInt(buf[:0], n, 10)
+	return l.writeDecimal(w, *(*[]byte)(noescape(unsafe.Pointer(&b))))
+}
+
+// WriteUint writes n using the grouping separator of the locale.
+func (l *Locale) WriteUint(w io.Writer, n uint64) error {
+	var buf [24]byte
+	b := strconv.AppendUint(buf[:0], n, 10)
+	return l.writeDecimal(w, *(*[]byte)(noescape(unsafe.Pointer(&b))))
+}
+
+// WriteFloat writes f using the decimal and grouping separators of the locale.
+// Very large and very small numbers are written in exponent notation as by WriteFloat.
+func (l *Locale) WriteFloat(w io.Writer, f float64) error {
+	if a := math.Abs(f); math.IsNaN(f) || a >= 1e21 || (a < 1e-6 && a != 0) {
+		return WriteFloat(w, f)
+	}
+	var buf [64]byte
+	b := strconv.AppendFloat(buf[:0], f, 'f', -1, 64)
+	return l.writeDecimal(w, *(*[]byte)(noescape(unsafe.Pointer(&b))))
+}
+
+// writeDecimal writes a plain decimal number (e.g. "-1234.5") using the locale separators.
+func (l *Locale) writeDecimal(w io.Writer, b []byte) error {
+	if len(b) > 0 && b[0] == '-' {
+		if _, err := w.Write(b[:1]); err != nil {
+			return err
+		}
+		b = b[1:]
+	}
+	intPart, frac := b, []byte(nil)
+	for i, c := range b {
+		if c == '.' {
+			intPart, frac = b[:i], b[i+1:]
+			break
+		}
+	}
+
+	groupMin := l.GroupMin
+	if groupMin < 1 {
+		groupMin = 1
+	}
+	if l.Group == "" || len(intPart) < 3+groupMin {
+		if _, err := w.Write(intPart); err != nil {
+			return err
+		}
+	} else {
+		first := len(intPart) % 3
+		if first == 0 {
+			first = 3
+		}
+		if _, err := w.Write(intPart[:first]); err != nil {
+			return err
+		}
+		for i := first; i < len(intPart); i += 3 {
+			if _, err := WriteString(EscapeWriter(w.Write), l.Group); err != nil {
+				return err
+			}
+			if _, err := w.Write(intPart[i : i+3]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(frac) > 0 {
+		dec := l.Decimal
+		if dec == "" {
+			dec = "."
+		}
+		if _, err := WriteString(EscapeWriter(w.Write), dec); err != nil {
+			return err
+		}
+		if _, err := w.Write(frac); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendMoneyAmount appends the absolute amount as a plain decimal number.
+func appendMoneyAmount(b []byte, minor int64, digits int) []byte {
+	u := uint64(minor)
+	if minor < 0 {
+		u = uint64(^minor + 1)
+	}
+	if digits <= 0 {
+		return strconv.AppendUint(b, u, 10)
+	}
+	scale := pow10(digits)
+	b = strconv.AppendUint(b, u/scale, 10)
+	b = append(b, '.')
+	frac := u % scale
+	for s := scale / 10; s > 1 && frac < s; s /= 10 {
+		b = append(b, '0')
+	}
+	return strconv.AppendUint(b, frac, 10)
+}
+
+var (
+	minusSign = []byte("-")
+	spaceSign = []byte(" ")
+)
+
+// writeMoney writes the amount using the currency pattern of the locale.
+// Without a locale the amount is written as a plain number followed by the currency code.
+func (l *Locale) writeMoney(w io.Writer, minor int64, c *Currency) error {
+	var buf [32]byte
+	b := appendMoneyAmount(buf[:0], minor, c.Digits)
+	amount := *(*[]byte)(noescape(unsafe.Pointer(&b)))
+
+	if minor < 0 {
+		if _, err := w.Write(minusSign); err != nil {
+			return err
+		}
+	}
+	if l == nil || l.CurrencyPattern == "" {
+		if _, err := w.Write(amount); err != nil {
+			return err
+		}
+		if _, err := w.Write(spaceSign); err != nil {
+			return err
+		}
+		_, err := WriteString(EscapeWriter(w.Write), c.Code)
+		return err
+	}
+
+	symbol := c.Symbol
+	if symbol == "" {
+		symbol = c.Code
+	}
+	p := l.CurrencyPattern
+	for len(p) > 0 {
+		i := strings.IndexAny(p, "#¤")
+		if i < 0 {
+			break
+		}
+		if i > 0 {
+			if _, err := WriteString(EscapeWriter(w.Write), p[:i]); err != nil {
+				return err
+			}
+		}
+		if p[i] == '#' {
+			if err := l.writeDecimal(w, amount); err != nil {
+				return err
+			}
+			p = p[i+1:]
+		} else {
+			if _, err := WriteString(EscapeWriter(w.Write), symbol); err != nil {
+				return err
+			}
+			p = p[i+len("¤"):]
+		}
+	}
+	if len(p) > 0 {
+		_, err := WriteString(EscapeWriter(w.Write), p)
+		return err
+	}
+	return nil
+}
+
+// writeHumanValue writes a time, date, duration or money value in human-readable form, HTML-escaped.
+// For times and dates a non-empty layout takes precedence over the render context and its locale.
+func writeHumanValue(w io.Writer, v TypedValue, layout string) error {
+	ctx := ContextOf(w)
+	var buf [64]byte
+	var b []byte
+	switch v.Kind() {
+	case KindTime:
+		if layout == "" {
+			layout = ctx.timeLayout()
+		}
+		b = v.TimeOrZero().AppendFormat(buf[:0], layout)
+	case KindDate:
+		if layout == "" {
+			layout = ctx.dateLayout()
+		}
+		b = v.TimeOrZero().AppendFormat(buf[:0], layout)
+	case KindDuration:
+		if ctx != nil && ctx.DurationFormat != nil {
+			_, err := EscapeWriter(w.Write).Write(ctx.DurationFormat(make([]byte, 0, 32), time.Duration(v.num)))
+			return err
+		}
+		b = appendDuration(buf[:0], time.Duration(v.num))
+	case KindMoney:
+		amount, c, _ := v.Money()
+		return ctx.locale().writeMoney(w, amount, c)
+	default:
+		return nil
+	}
+	_, err := EscapeWriter(w.Write).Write(*(*[]byte)(noescape(unsafe.Pointer(&b))))
+	return err
+}
+
+// writeMachineValue writes a time, date, duration or money value in a machine-readable form
+// suitable for attributes: RFC 3339 for times, YYYY-MM-DD for dates,
+// ISO 8601 for durations and a plain decimal number for money.
+// The output never contains characters that need to be escaped.
+func writeMachineValue(w io.Writer, v TypedValue) error {
+	var buf [64]byte
+	var b []byte
+	switch v.Kind() {
+	case KindTime:
+		b = v.TimeOrZero().AppendFormat(buf[:0], time.RFC3339Nano)
+	case KindDate:
+		b = v.TimeOrZero().AppendFormat(buf[:0], time.DateOnly)
+	case KindDuration:
+		b = appendISODuration(buf[:0], time.Duration(v.num))
+	case KindMoney:
+		amount, c, _ := v.Money()
+		if amount < 0 {
+			buf[0] = '-'
+			b = appendMoneyAmount(buf[:1], amount, c.Digits)
+		} else {
+			b = appendMoneyAmount(buf[:0], amount, c.Digits)
+		}
+	default:
+		return nil
+	}
+	_, err := w.Write(*(*[]byte)(noescape(unsafe.Pointer(&b))))
+	return err
+}
+
+// appendDuration appends d in the same format as time.Duration.String.
+func appendDuration(b []byte, d time.Duration) []byte {
+	u := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+	if u < uint64(time.Second) {
+		switch {
+		case u == 0:
+			return append(b, "0s"...)
+		case u < uint64(time.Microsecond):
+			b = strconv.AppendUint(b, u, 10)
+			return append(b, "ns"...)
+		case u < uint64(time.Millisecond):
+			b = appendFrac(b, u, 3)
+			return append(b, "µs"...)
+		default:
+			b = appendFrac(b, u, 6)
+			return append(b, "ms"...)
+		}
+	}
+	h := u / uint64(time.Hour)
+	u -= h * uint64(time.Hour)
+	m := u / uint64(time.Minute)
+	u -= m * uint64(time.Minute)
+	if h > 0 {
+		b = strconv.AppendUint(b, h, 10)
+		b = append(b, 'h')
+	}
+	if h > 0 || m > 0 {
+		b = strconv.AppendUint(b, m, 10)
+		b = append(b, 'm')
+	}
+	b = appendFrac(b, u, 9)
+	return append(b, 's')
+}
+
+// appendISODuration appends d as an ISO 8601 duration (e.g. "PT1H30M5.5S").
+func appendISODuration(b []byte, d time.Duration) []byte {
+	u := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+	b = append(b, 'P', 'T')
+	h := u / uint64(time.Hour)
+	u -= h * uint64(time.Hour)
+	m := u / uint64(time.Minute)
+	u -= m * uint64(time.Minute)
+	if h > 0 {
+		b = strconv.AppendUint(b, h, 10)
+		b = append(b, 'H')
+	}
+	if m > 0 {
+		b = strconv.AppendUint(b, m, 10)
+		b = append(b, 'M')
+	}
+	if u > 0 || (h == 0 && m == 0) {
+		b = appendFrac(b, u, 9)
+		b = append(b, 'S')
+	}
+	return b
+}
+
+// appendFrac appends v / 10^prec with trailing zeros of the fraction removed.
+func appendFrac(b []byte, v uint64, prec int) []byte {
+	scale := pow10(prec)
+	b = strconv.AppendUint(b, v/scale, 10)
+	frac := v % scale
+	if frac == 0 {
+		return b
+	}
+	b = append(b, '.')
+	for s := scale / 10; frac < s; s /= 10 {
+		b = append(b, '0')
+	}
+	for frac%10 == 0 {
+		frac /= 10
+	}
+	return strconv.AppendUint(b, frac, 10)
+}
+
+func pow10(n int) uint64 {
+	p := uint64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+// TimeText creates a text node with the time formatted using layout.
+// If layout is empty, the time is formatted according to the render context
+// (see Context.TimeLayout and Locale.DateTimeLayout). The zero time renders nothing.
+func TimeText(t time.Time, layout string) *Node {
+	if t.IsZero() {
+		return nil
+	}
+	n := Get()
+	n.tag = "$text"
+	n.value = TimeValue(t)
+	if layout != "" {
+		n.vars = append(n.vars, valueEntry{name: "layout", value: String(layout)})
+	}
+	n.writeFn = renderTimeText
+	return n
+}
+
+func renderTimeText(n *Node, w io.Writer) error {
+	var layout string
+	if len(n.vars) > 0 {
+		layout = n.GetVar("layout").StringOrZero()
+	}
+	return writeHumanValue(w, n.value, layout)
+}
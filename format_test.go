@@ -0,0 +1,167 @@
+package htm
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_Format_LocaleNumbers(t *testing.T) {
+	cases := []struct {
+		tag  string
+		v    TypedValue
+		want string
+	}{
+		{"en", Float(1234.5), "1,234.5"},
+		{"de", Float(-1234567.25), "-1.234.567,25"},
+		{"es", Int(1234), "1234"},
+		{"es", Int(12345), "12.345"},
+		{"ru", Uint(1000000), "1 000 000"},
+		{"en", Int(999), "999"},
+		{"en", Float(1e22), "1e+22"},
+	}
+	for _, c := range cases {
+		n := TextValue(c.v)
+		if got := renderWith(t, n, &Context{Locale: NewLocale(c.tag, nil)}); got != c.want {
+			t.Fatalf("%s: expected %q, got %q", c.tag, c.want, got)
+		}
+		n.Release()
+	}
+
+	n := TextValue(Float(1234.5))
+	defer n.Release()
+	if got := n.String(); got != "1234.5" {
+		t.Fatalf("expected plain number without locale, got %q", got)
+	}
+}
+
+func Test_Format_MoneyTimeDuration(t *testing.T) {
+	ts := time.Date(2024, 3, 9, 14, 5, 0, 0, time.UTC)
+
+	cases := []struct {
+		tag  string
+		v    TypedValue
+		want string
+	}{
+		{"en", Money(123450, USD), "$1,234.50"},
+		{"de", Money(-5, EUR), "-0,05 €"},
+		{"ja", Money(1500, JPY), "¥1,500"},
+		{"", Money(123405, USD), "1234.05 USD"},
+		{"en", DateValue(ts), "03/09/2024"},
+		{"de", DateValue(ts), "09.03.2024"},
+		{"en-GB", TimeValue(ts), "09/03/2024 14:05"},
+		{"", TimeValue(ts), "2024-03-09 14:05:00"},
+		{"", DurationValue(90*time.Minute + 1500*time.Millisecond), "1h30m1.5s"},
+		{"", DurationValue(1050 * time.Microsecond), "1.05ms"},
+	}
+	for _, c := range cases {
+		ctx := &Context{}
+		if c.tag != "" {
+			ctx.Locale = NewLocale(c.tag, nil)
+		}
+		n := TextValue(c.v)
+		if got := renderWith(t, n, ctx); got != c.want {
+			t.Fatalf("%s: expected %q, got %q", c.tag, c.want, got)
+		}
+		n.Release()
+	}
+}
+
+func Test_Format_AttributesAreMachineReadable(t *testing.T) {
+	ts := time.Date(2024, 3, 9, 14, 5, 0, 0, time.UTC)
+
+	n := Div().
+		AttrValue("data-t", TimeValue(ts)).
+		AttrValue("data-d", DateValue(ts)).
+		AttrValue("data-dur", DurationValue(90*time.Minute+5*time.Second)).
+		AttrValue("data-m", Money(-123405, USD)).
+		AttrValue("data-f", Float(1234.5))
+	defer n.Release()
+
+	want := `<div data-t="2024-03-09T14:05:00Z" data-d="2024-03-09" data-dur="PT1H30M5S" data-m="-1234.05" data-f="1234.5"></div>`
+	if got := renderWith(t, n, &Context{Locale: NewLocale("de", nil)}); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Format_NoAllocations(t *testing.T) {
+	n := Div().Content(
+		TextValue(Float(1234.5)),
+		TextValue(Money(123450, EUR)),
+		TextValue(TimeValue(time.Date(2024, 3, 9, 14, 5, 0, 0, time.UTC))),
+		TextValue(DurationValue(time.Minute)),
+	)
+	defer n.Release()
+
+	w := WithLocale(io.Discard, NewLocale("de", nil))
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := n.Render(w); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func Test_Format_TimeTagAndContextLayouts(t *testing.T) {
+	ts := time.Date(2024, 3, 9, 14, 5, 0, 0, time.UTC)
+
+	n := Div().Content(
+		TimeTag(ts, ""),
+		TimeTag(ts, "Jan 2, 2006"),
+		DurationTag(90*time.Minute),
+	)
+	defer n.Release()
+
+	want := `<div><time datetime="2024-03-09T14:05:00Z">09.03.2024 14:05</time>` +
+		`<time datetime="2024-03-09T14:05:00Z">Mar 9, 2024</time>` +
+		`<time datetime="PT1H30M">1h30m0s</time></div>`
+	if got := renderWith(t, n, &Context{Locale: NewLocale("de", nil)}); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	ctx := &Context{
+		Locale:     NewLocale("de", nil),
+		TimeLayout: "15:04",
+		DurationFormat: func(b []byte, d time.Duration) []byte {
+			return append(b, "<"+d.Round(time.Hour).String()+">"...)
+		},
+	}
+	want = `<div><time datetime="2024-03-09T14:05:00Z">14:05</time>` +
+		`<time datetime="2024-03-09T14:05:00Z">Mar 9, 2024</time>` +
+		`<time datetime="PT1H30M">&lt;2h0m0s&gt;</time></div>`
+	if got := renderWith(t, n, ctx); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	in := Input().Type("date").MinDate(ts).MaxValue(DateValue(ts.AddDate(0, 1, 0)))
+	defer in.Release()
+	if got, want := in.String(), `<input type="date" min="2024-03-09" max="2024-04-09"/>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Format_TimesOutsideUnixNano(t *testing.T) {
+	first := time.Date(1, 1, 1, 12, 0, 0, 0, time.UTC) // midnight is the zero time, which is Unset
+	last := time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	n := Div().Content(
+		Time().DateTimeValue(DateValue(first)),
+		TimeTag(last, "2006-01-02 15:04:05"),
+		Build("data").AttrValue("value", DateValue(time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC))),
+	)
+	defer n.Release()
+	want := `<div><time datetime="0001-01-01"></time>` +
+		`<time datetime="9999-12-31T23:59:59Z">9999-12-31 23:59:59</time>` +
+		`<data value="1500-01-01"></data></div>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if got, ok := TimeValue(last).Time(); !ok || !got.Equal(last) || TimeValue(last).Kind() != KindTime {
+		t.Fatalf("unexpected time: %v", got)
+	}
+	if got := DateValue(first).TimeOrZero(); !got.Equal(first) || DateValue(first).Kind() != KindDate {
+		t.Fatalf("unexpected date: %v", got)
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
@@ -25,6 +27,7 @@ type Node struct {
 
 	attrs *attrMap
 	class *classMap
+	style *attrMap
 	vars  []valueEntry
 
 	content []*Node
@@ -54,6 +57,7 @@ const (
 	flagVoid = 1 << iota
 	flagOwned
 	flagScript
+	flagStyle // the style attribute is set, even if it has no properties
 )
 
 /**/
@@ -215,6 +219,9 @@ func (n *Node) SetTagEx(tag string, void bool) *Node {
 // GetAttr retrieves the attribute value by name. Returns a zero value if not found.
 // Use TypedValue.Valid to check for validity.
 func (n *Node) GetAttr(name string) TypedValue {
+	if name == "style" {
+		return n.styleAttr()
+	}
 	v, _ := n.attrs.get(name)
 	return v
 }
@@ -255,7 +262,13 @@ func AttrValue(name string, value ...TypedValue) Mod {
 // If value is omitted, it sets a boolean attribute.
 // To unset a boolean attribute, use BoolAttr(name, false) or RemoveAttr(name) or AttrValue(name, Unset).
 // Attr should not be used to set a class attribute; use Class instead.
+// The style attribute replaces the inline style properties, see Style.
 func (n *Node) Attr(name string, value ...string) *Node {
+	if name == "style" && len(value) > 0 {
+		return n.AttrValue(name, String(value[0]))
+	} else if name == "style" {
+		return n.AttrValue(name)
+	}
 	if len(value) > 0 {
 		n.attrs.set(name, String(value[0]))
 		return n
@@ -268,6 +281,11 @@ func (n *Node) Attr(name string, value ...string) *Node {
 // If value is omitted, it is treated as enabled.
 // To unset a boolean attribute, use BoolAttr(name, false) or RemoveAttr(name) or AttrValue(name, Unset).
 func (n *Node) AttrBool(name string, value ...bool) *Node {
+	if name == "style" && len(value) > 0 {
+		return n.AttrValue(name, Bool(value[0]))
+	} else if name == "style" {
+		return n.AttrValue(name)
+	}
 	if len(value) > 0 {
 		n.attrs.set(name, Bool(value[0]))
 		return n
@@ -281,6 +299,14 @@ func (n *Node) AttrBool(name string, value ...bool) *Node {
 // To unset a boolean attribute, use AttrValue(name, Unset) or RemoveAttr(name).
 // AttrValue should not be used to set a class attribute; use Class instead.
 func (n *Node) AttrValue(name string, value ...TypedValue) *Node {
+	if name == "style" {
+		v := Bool(true)
+		if len(value) > 0 {
+			v = value[0]
+		}
+		n.setStyleAttr(v)
+		return n
+	}
 	if len(value) > 0 {
 		n.attrs.set(name, value[0])
 		return n
@@ -292,27 +318,52 @@ func (n *Node) AttrValue(name string, value ...TypedValue) *Node {
 // RemoveAttr removes the specified attributes from the node.
 func (n *Node) RemoveAttr(names ...string) *Node {
 	for _, name := range names {
+		if name == "style" {
+			n.setStyleAttr(Unset)
+			continue
+		}
 		n.attrs.set(name, Unset)
 	}
 	return n
 }
 
 // HasAttr checks if the node has at least one of the specified attributes.
-func (n *Node) HasAttr(names ...string) bool { return n.attrs.hasAny(names...) }
+func (n *Node) HasAttr(names ...string) bool {
+	return n.attrs.hasAny(names...) || n.hasStyleAttr() && slices.Contains(names, "style")
+}
 
 // HasAttrAll checks if the node has all of the specified attributes.
-func (n *Node) HasAttrAll(names ...string) bool { return n.attrs.hasAll(names...) }
+func (n *Node) HasAttrAll(names ...string) bool {
+	for _, name := range names {
+		if !n.HasAttr(name) {
+			return false
+		}
+	}
+	return true
+}
 
 // HasAttrPrefix checks if the node has any attribute starting with the given prefix.
-func (n *Node) HasAttrPrefix(prefix string) bool { return n.attrs.hasPrefix(prefix) }
+func (n *Node) HasAttrPrefix(prefix string) bool {
+	return n.attrs.hasPrefix(prefix) || n.hasStyleAttr() && strings.HasPrefix("style", prefix)
+}
 
 // HasAttrSuffix checks if the node has any attribute ending with the given suffix.
-func (n *Node) HasAttrSuffix(suffix string) bool { return n.attrs.hasSuffix(suffix) }
+func (n *Node) HasAttrSuffix(suffix string) bool {
+	return n.attrs.hasSuffix(suffix) || n.hasStyleAttr() && strings.HasSuffix("style", suffix)
+}
 
 // EachAttr iterates over all attributes, calling fn for each.
+// The style attribute, if set, comes last.
 // Iteration stops if fn returns false.
 func (n *Node) EachAttr(fn func(string, TypedValue) bool) *Node {
-	n.attrs.each(fn)
+	stopped := false
+	n.attrs.each(func(name string, v TypedValue) bool {
+		stopped = !fn(name, v)
+		return !stopped
+	})
+	if !stopped && n.hasStyleAttr() {
+		fn("style", n.styleAttr())
+	}
 	return n
 }
 
@@ -322,7 +373,9 @@ func (n *Node) MoveAttrTo(dst *Node, names ...string) *Node {
 		return n
 	}
 	for _, name := range names {
-		if v, ok := n.attrs.extract(name); ok {
+		if name == "style" {
+			n.moveStyleAttrTo(dst)
+		} else if v, ok := n.attrs.extract(name); ok {
 			dst.attrs.set(name, v)
 		}
 	}
@@ -335,6 +388,9 @@ func (n *Node) MoveAttrPrefixTo(dst *Node, prefix string) *Node {
 		return n
 	}
 	n.attrs.movePrefixTo(dst.attrs, prefix)
+	if strings.HasPrefix("style", prefix) {
+		n.moveStyleAttrTo(dst)
+	}
 	return n
 }
 
@@ -344,6 +400,9 @@ func (n *Node) MoveAttrSuffixTo(dst *Node, suffix string) *Node {
 		return n
 	}
 	n.attrs.moveSuffixTo(dst.attrs, suffix)
+	if strings.HasSuffix("style", suffix) {
+		n.moveStyleAttrTo(dst)
+	}
 	return n
 }
 
@@ -499,6 +558,119 @@ func (n *Node) MoveClassSuffix(suffixes ...string) Mod {
 
 /**/
 
+// GetStyle retrieves the value of an inline style property. Returns a zero value if not found.
+func (n *Node) GetStyle(name string) TypedValue {
+	v, _ := n.style.get(name)
+	return v
+}
+
+// SetStyle returns a Mod that sets an inline style property.
+func SetStyle(name string, value TypedValue) Mod {
+	return func(n *Node) { n.SetStyle(name, value) }
+}
+
+// SetStyle sets the value of an inline style property, e.g. SetStyle("width", Px(120)).
+// String values are escaped for the style context.
+// Properties set with SetStyle and Style are merged; the last value of a property wins.
+func (n *Node) SetStyle(name string, value TypedValue) *Node {
+	n.style.set(name, value)
+	return n
+}
+
+// RemoveStyle removes the specified inline style properties from the node.
+func (n *Node) RemoveStyle(names ...string) *Node {
+	for _, name := range names {
+		n.style.set(name, Unset)
+	}
+	return n
+}
+
+// HasStyle checks if the node has at least one of the specified style properties.
+func (n *Node) HasStyle(names ...string) bool { return n.style.hasAny(names...) }
+
+// HasStyleAll checks if the node has all of the specified style properties.
+func (n *Node) HasStyleAll(names ...string) bool { return n.style.hasAll(names...) }
+
+// HasStylePrefix checks if the node has any style property starting with the given prefix.
+func (n *Node) HasStylePrefix(prefix string) bool { return n.style.hasPrefix(prefix) }
+
+// EachStyle iterates over all style properties, calling fn for each.
+// Iteration stops if fn returns false.
+func (n *Node) EachStyle(fn func(string, TypedValue) bool) *Node {
+	n.style.each(fn)
+	return n
+}
+
+// MoveStyleTo moves specific style properties from the current node to the destination node.
+func (n *Node) MoveStyleTo(dst *Node, names ...string) *Node {
+	if n == dst {
+		return n
+	}
+	for _, name := range names {
+		if v, ok := n.style.extract(name); ok {
+			dst.style.set(name, v)
+		}
+	}
+	return n
+}
+
+// CopyStyleTo copies specific style properties from the current node to the destination node.
+func (n *Node) CopyStyleTo(dst *Node, names ...string) *Node {
+	if n == dst {
+		return n
+	}
+	for _, name := range names {
+		if v, ok := n.style.get(name); ok && v.Valid() {
+			dst.style.set(name, v)
+		}
+	}
+	return n
+}
+
+// MoveStylePrefixTo moves all style properties starting with the given prefix to the destination node.
+func (n *Node) MoveStylePrefixTo(dst *Node, prefix string) *Node {
+	if n == dst {
+		return n
+	}
+	n.style.movePrefixTo(dst.style, prefix)
+	return n
+}
+
+// CopyStylePrefixTo copies all style properties starting with the given prefix to the destination node.
+func (n *Node) CopyStylePrefixTo(dst *Node, prefix string) *Node {
+	if n == dst {
+		return n
+	}
+	for _, e := range n.style.o {
+		if e.value.Valid() && strings.HasPrefix(e.name, prefix) {
+			dst.style.set(e.name, e.value)
+		}
+	}
+	return n
+}
+
+// MoveStyle returns a Mod that moves specific style properties to a destination node.
+func (n *Node) MoveStyle(names ...string) Mod {
+	return func(dst *Node) { n.MoveStyleTo(dst, names...) }
+}
+
+// CopyStyle returns a Mod that copies specific style properties to a destination node.
+func (n *Node) CopyStyle(names ...string) Mod {
+	return func(dst *Node) { n.CopyStyleTo(dst, names...) }
+}
+
+// MoveStylePrefix returns a Mod that moves style properties with a specific prefix to a destination node.
+func (n *Node) MoveStylePrefix(prefix string) Mod {
+	return func(dst *Node) { n.MoveStylePrefixTo(dst, prefix) }
+}
+
+// CopyStylePrefix returns a Mod that copies style properties with a specific prefix to a destination node.
+func (n *Node) CopyStylePrefix(prefix string) Mod {
+	return func(dst *Node) { n.CopyStylePrefixTo(dst, prefix) }
+}
+
+/**/
+
 // GetVar retrieves the value of a user variable by name. Returns unset value if not found.
 func (n *Node) GetVar(name string) TypedValue {
 	for _, v := range n.vars {
@@ -634,21 +806,55 @@ func (n *Node) MoveVarSuffixTo(dst *Node, prefix string) *Node {
 
 /**/
 
-var staticMap sync.Map
+// StaticCache holds rendered output of Static nodes. The zero value is ready to use.
+// A Renderer may have its own cache, so static fragments rendered with different output syntax don't mix.
+type StaticCache struct {
+	m sync.Map
+}
 
-// Static renders the node returned by fn once and caches the result globally.
-// Subsequent calls return a cached raw byte node, avoiding re-rendering.
+var staticMap StaticCache
+
+// Static renders the node returned by fn once and caches the result.
+// Subsequent renders write the cached bytes, avoiding re-rendering.
+// The result is cached in the cache of the Renderer, if it has one, or globally.
+// As the output is shared by all renders, it is rendered without the render context:
+// the locale, translations, ID generator and hooks of the caller don't apply,
+// only the output syntax of a Renderer with its own cache does.
 func Static(fn func() *Node) *Node {
+	n := Get()
+	n.tag = "$static"
+	n.value = TypedValue{num: uint64(KindAny), any: fn}
+	n.writeFn = renderStatic
+	return n
+}
+
+func renderStatic(n *Node, w io.Writer) error {
+	fn := n.value.any.(func() *Node)
+	cache := &staticMap
+	var opts *Renderer
+	if r := ContextOf(w).renderer(); r != nil && r.Static != nil {
+		cache = r.Static
+		// the cache belongs to the renderer, so its output syntax applies;
+		// the context and hooks of the render that fills the cache do not
+		opts = &Renderer{StripComments: r.StripComments, Dialect: r.Dialect, Escaping: r.Escaping, Scripts: r.Scripts,
+			StrictSlots: r.StrictSlots, ClassMerge: r.ClassMerge}
+	}
 	ptr := reflect.ValueOf(fn).Pointer()
-	if v, ok := staticMap.Load(ptr); ok {
-		return RawBytes(v.([]byte))
+	if v, ok := cache.m.Load(ptr); ok {
+		_, err := w.Write(v.([]byte))
+		return err
 	}
 	var buf bytes.Buffer
-	if err := fn().Render(&buf); err != nil {
-		return RawBytes([]byte(err.Error()))
+	var dst io.Writer = &buf
+	if opts != nil {
+		dst = WithContext(dst, &Context{Renderer: opts})
+	}
+	if err := fn().Render(dst); err != nil {
+		return err
 	}
-	staticMap.Store(ptr, buf.Bytes())
-	return RawBytes(buf.Bytes())
+	v, _ := cache.m.LoadOrStore(ptr, buf.Bytes())
+	_, err := w.Write(v.([]byte))
+	return err
 }
 
 // StaticContent sets the content of the node to the cached output of fn.
@@ -704,6 +910,14 @@ func (n *Node) TextValue(v TypedValue) *Node {
 	return n.Content(TextValue(v))
 }
 
+// GetText returns the value of a text or raw node. Returns a zero value for other nodes.
+func (n *Node) GetText() TypedValue {
+	if n.tag != "$text" && n.tag != "$raw" {
+		return Unset
+	}
+	return n.value
+}
+
 /**/
 
 // Append adds nodes to the end of the content.
@@ -892,6 +1106,65 @@ func (n *Node) ExtractSlot(name string) (extracted []*Node) {
 	return nil
 }
 
+// SlotOutlet creates a placeholder for the content of a named slot.
+// Components place outlets in their content tree; when the closest ancestor
+// that has slots is rendered, the content of the slot is moved into the outlet.
+// If the slot is empty, the fallback content is rendered instead.
+func SlotOutlet(name string, fallback ...*Node) *Node {
+	n := Get()
+	n.tag = "$outlet"
+	n.value = String(name)
+	n.content = append(n.content, fallback...)
+	n.writeFn = renderGroup
+	return n
+}
+
+// fillOutlets moves the content of slots into the outlets found in the content tree.
+// If strict is set, a slot with content but without an outlet is an error.
+func (n *Node) fillOutlets(strict bool) error {
+	for i := range n.slots {
+		slot := &n.slots[i]
+		if len(slot.content) == 0 {
+			continue
+		}
+		if outlet := findOutlet(n.content, slot.name); outlet != nil {
+			for _, node := range outlet.content {
+				put(node)
+			}
+			clear(outlet.content)
+			outlet.content = append(outlet.content[:0], slot.content...)
+			clear(slot.content)
+			slot.content = slot.content[:0]
+			continue
+		}
+		if strict {
+			return fmt.Errorf("htm: slot %q has no outlet in <%v>", slot.name, n.tag)
+		}
+	}
+	return nil
+}
+
+func findOutlet(nodes []*Node, name string) *Node {
+	for _, c := range nodes {
+		if c == nil {
+			continue
+		}
+		if c.tag == "$outlet" {
+			if c.value.StringOrZero() == name {
+				return c
+			}
+			continue
+		}
+		if len(c.slots) > 0 {
+			continue // outlets below belong to a nested component
+		}
+		if outlet := findOutlet(c.content, name); outlet != nil {
+			return outlet
+		}
+	}
+	return nil
+}
+
 // MoveSlotTo moves named slots and their content to the destination node.
 func (n *Node) MoveSlotTo(dst *Node, names ...string) *Node {
 NAMES:
@@ -1022,43 +1295,69 @@ var (
 	falseValue = []byte("false")
 )
 
-func renderText(n *Node, w io.Writer) (err error) {
-	switch n.value.Kind() {
+func renderText(n *Node, w io.Writer) error {
+	return writeText(w, n.value)
+}
+
+// writeText writes the HTML-escaped text representation of v.
+func writeText(w io.Writer, v TypedValue) (err error) {
+	switch v.Kind() {
 
 	case KindNone:
 		return
 
 	case KindAny:
-		_, err = fmt.Fprint(EscapeWriter(w.Write), n.value.any)
+		_, err = fmt.Fprint(EscapeWriter(w.Write), v.any)
 
 	case KindBool:
-		if n.value.num == 1 {
+		if v.num == 1 {
 			_, err = w.Write(trueValue)
 		} else {
 			_, err = w.Write(falseValue)
 		}
 
 	case KindInt64:
-		err = WriteInt(w, int64(n.value.num))
+		if l := LocaleOf(w); l != nil {
+			err = l.WriteInt(w, int64(v.num))
+		} else {
+			err = WriteInt(w, int64(v.num))
+		}
 
 	case KindUint64:
-		err = WriteUint(w, n.value.num)
+		if l := LocaleOf(w); l != nil {
+			err = l.WriteUint(w, v.num)
+		} else {
+			err = WriteUint(w, v.num)
+		}
 
 	case KindFloat64:
-		err = WriteFloat(w, math.Float64frombits(n.value.num))
+		if l := LocaleOf(w); l != nil {
+			err = l.WriteFloat(w, math.Float64frombits(v.num))
+		} else {
+			err = WriteFloat(w, math.Float64frombits(v.num))
+		}
+
+	case KindTime, KindDate, KindDuration, KindMoney:
+		err = writeHumanValue(w, v, "")
+
+	case KindLength:
+		err = writeLength(w, v)
+
+	case KindID:
+		err = writeID(w, v)
 
 	case KindString:
-		s := unsafe.Slice(n.value.any.(stringptr), n.value.num)
+		s := unsafe.Slice(v.any.(stringptr), v.num)
 		_, err = EscapeWriter(w.Write).Write(s)
 
 	case KindBytes:
-		s := unsafe.Slice(n.value.any.(byteptr), n.value.num)
+		s := unsafe.Slice(v.any.(byteptr), v.num)
 		_, err = EscapeWriter(w.Write).Write(s)
 
 	case KindJSON:
 		buf := jsonBufPool.Get().(*bytes.Buffer)
 		buf.Reset()
-		if err = json.NewEncoder(buf).Encode(n.value.any); err != nil {
+		if err = json.NewEncoder(buf).Encode(v.any); err != nil {
 			jsonBufPool.Put(buf)
 			return err
 		}
@@ -1070,7 +1369,7 @@ func renderText(n *Node, w io.Writer) (err error) {
 		jsonBufPool.Put(buf)
 
 	default:
-		_, err = fmt.Fprint(EscapeWriter(w.Write), n.value.any)
+		_, err = fmt.Fprint(EscapeWriter(w.Write), v.any)
 
 	}
 	return
@@ -1097,10 +1396,49 @@ func (n *Node) Render(w io.Writer) error {
 	for _, fn := range n.postponed {
 		fn(n)
 	}
+	ctx := ContextOf(w)
+	r := ctx.renderer()
+	if len(n.slots) > 0 {
+		if err := n.fillOutlets(r != nil && r.StrictSlots); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return n.renderElement(w, nil, nil)
+	}
+	if r.Scripts == ScriptStrip && isScriptTag(n.tag) {
+		return nil
+	}
+	if !r.beforeElement(n) {
+		return nil
+	}
+	if r.Validator != nil {
+		r.Validator.check(ctx.open, n)
+		ctx.open = append(ctx.open, n)
+	}
+	if err := n.renderElement(w, ctx, r); err != nil {
+		return err
+	}
+	if r.Validator != nil {
+		ctx.open = ctx.open[:len(ctx.open)-1]
+	}
+	r.afterElement(n)
+	return nil
+}
+
+// renderElement writes the element; ctx and r are nil unless it is rendered by a Renderer.
+func (n *Node) renderElement(w io.Writer, ctx *Context, r *Renderer) error {
 	if !ValidTag(n.tag) {
 		return fmt.Errorf("invalid tag: %v", n.tag)
 	}
 
+	// only block elements start on a new line, so the whitespace does not change the rendered text
+	pretty := r != nil && r.Indent != "" && ctx.preformatted == 0
+	if pretty && isBlock(n.tag) {
+		if err := ctx.writeIndent(w, r.Indent); err != nil {
+			return err
+		}
+	}
 	if _, err := w.Write(openingPart); err != nil {
 		return err
 	}
@@ -1108,7 +1446,16 @@ func (n *Node) Render(w io.Writer) error {
 		return err
 	}
 	if len(n.class.o) > 0 {
-		if err := writeClass(w, n.class.o); err != nil {
+		var merge ClassResolver
+		if r != nil {
+			merge = r.ClassMerge
+		}
+		if err := writeClass(w, n.class.o, merge); err != nil {
+			return err
+		}
+	}
+	if len(n.style.o) > 0 || n.flag&flagStyle != 0 {
+		if err := writeStyle(w, n.style.o, n.flag&flagStyle != 0); err != nil {
 			return err
 		}
 	}
@@ -1117,22 +1464,69 @@ func (n *Node) Render(w io.Writer) error {
 			return err
 		}
 	}
+	if r != nil && r.KeyAttr != "" {
+		if err := n.writeKey(w, r.KeyAttr); err != nil {
+			return err
+		}
+	}
+	if n.tag == "html" {
+		if l := LocaleOf(w); l != nil {
+			if err := writeLocaleAttributes(w, n.attrs, l); err != nil {
+				return err
+			}
+		}
+	}
 	if n.flag&flagVoid != 0 {
-		_, err := w.Write(closingPartVoid)
+		void := closingPartVoid
+		if r != nil && r.Dialect == DialectHTML5 {
+			void = closingPartRight
+		}
+		_, err := w.Write(void)
 		return err
 	}
 	if _, err := w.Write(closingPartRight); err != nil {
 		return err
 	}
 	if len(n.content) > 0 {
-		if (n.flag&flagScript == 0) && isScriptTag(n.tag) {
+		if (n.flag&flagScript == 0) && isScriptTag(n.tag) && (r == nil || r.Scripts != ScriptAllow) {
 			return fmt.Errorf("script tags are not allowed to have content, use UnsafeScript to bypass this error")
 		}
+		lines := 0
+		if pretty {
+			ctx.depth++
+			lines = ctx.lines
+		}
+		pre := pretty && isPreformatted(n.tag)
+		if pre {
+			ctx.preformatted++
+		}
+		var ascii asciiMode
+		if ctx != nil && ctx.ascii != nil {
+			// character references are not decoded in raw text
+			ascii = ctx.ascii.mode
+			if m := rawTextMode(n.tag); m != asciiHTML {
+				ctx.ascii.mode = m
+			}
+		}
 		for _, content := range n.content {
 			if err := content.Render(w); err != nil {
 				return err
 			}
 		}
+		if ctx != nil && ctx.ascii != nil {
+			ctx.ascii.mode = ascii
+		}
+		if pre {
+			ctx.preformatted--
+		}
+		if pretty {
+			ctx.depth--
+			if ctx.lines != lines {
+				if err := ctx.writeIndent(w, r.Indent); err != nil {
+					return err
+				}
+			}
+		}
 	}
 	if _, err := w.Write(closingPartLeft); err != nil {
 		return err
@@ -1165,13 +1559,18 @@ var (
 	closingPartRight = []byte(">")
 )
 
-func writeClass(w io.Writer, classes []classEntry) error {
+func writeClass(w io.Writer, classes []classEntry, res ClassResolver) error {
 	if _, err := w.Write(classPrefix); err != nil {
 		return err
 	}
+	var groups []classGroup
+	if res != nil {
+		var buf [16]classGroup
+		groups = classGroups(classes, res, buf[:])
+	}
 	first := true
-	for _, c := range classes {
-		if !c.active || !ValidClass(c.name) {
+	for i, c := range classes {
+		if !c.active || !ValidClass(c.name) || groups != nil && overridden(classes, groups, i) {
 			continue
 		}
 		if !first {
@@ -1191,10 +1590,22 @@ func writeClass(w io.Writer, classes []classEntry) error {
 }
 
 func writeAttributes(w io.Writer, attrs []valueEntry) error {
+	r := ContextOf(w).renderer()
 	for _, a := range attrs {
 		if !a.value.Valid() {
 			continue
 		}
+		if r != nil {
+			if r.Scripts == ScriptStrip && (isEventAttr(a.name) || a.name == "srcdoc" ||
+				scriptURLAttrs[a.name] && isScriptURL(a.value)) {
+				continue
+			}
+			if len(r.attrFilters) > 0 {
+				if a.value = r.filterAttr(a.name, a.value); !a.value.Valid() {
+					continue
+				}
+			}
+		}
 		if !ValidAttr(a.name) {
 			continue
 		}
@@ -1213,6 +1624,21 @@ func writeAttributes(w io.Writer, attrs []valueEntry) error {
 		}
 
 		if kind == KindBool {
+			if r != nil && r.Dialect == DialectXML {
+				// XML has no attribute minimization
+				if _, err := w.Write(equals); err != nil {
+					return err
+				}
+				if _, err := w.Write(quote); err != nil {
+					return err
+				}
+				if _, err := WriteString(w, a.name); err != nil {
+					return err
+				}
+				if _, err := w.Write(quote); err != nil {
+					return err
+				}
+			}
 			continue
 		}
 
@@ -1247,6 +1673,18 @@ func writeAttributes(w io.Writer, attrs []valueEntry) error {
 			if err := WriteFloat(w, math.Float64frombits(a.value.num)); err != nil {
 				return err
 			}
+		case KindTime, KindDate, KindDuration, KindMoney:
+			if err := writeMachineValue(w, a.value); err != nil {
+				return err
+			}
+		case KindLength:
+			if err := writeLength(w, a.value); err != nil {
+				return err
+			}
+		case KindID:
+			if err := writeID(w, a.value); err != nil {
+				return err
+			}
 		case KindJSON:
 			buf := jsonBufPool.Get().(*bytes.Buffer)
 			buf.Reset()
@@ -1276,6 +1714,38 @@ func writeAttributes(w io.Writer, attrs []valueEntry) error {
 	return nil
 }
 
+var (
+	langPrefix = []byte(` lang="`)
+	dirPrefix  = []byte(` dir="`)
+)
+
+// writeLocaleAttributes writes lang and dir attributes of the locale unless they are set explicitly.
+func writeLocaleAttributes(w io.Writer, attrs *attrMap, l *Locale) error {
+	if l.Tag != "" && !attrs.hasAny("lang") {
+		if _, err := w.Write(langPrefix); err != nil {
+			return err
+		}
+		if _, err := WriteString(EscapeWriter(w.Write), l.Tag); err != nil {
+			return err
+		}
+		if _, err := w.Write(quote); err != nil {
+			return err
+		}
+	}
+	if l.Dir != "" && !attrs.hasAny("dir") {
+		if _, err := w.Write(dirPrefix); err != nil {
+			return err
+		}
+		if _, err := WriteString(EscapeWriter(w.Write), l.Dir); err != nil {
+			return err
+		}
+		if _, err := w.Write(quote); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // EscapeWriter is an io.Writer that escapes HTML special characters.
 type EscapeWriter func(p []byte) (n int, err error)
 
@@ -1410,6 +1880,7 @@ var nodePool = sync.Pool{
 			tag:   "div",
 			attrs: newAttrMap(),
 			class: newClassMap(),
+			style: &attrMap{},
 		}
 
 		if NoPool {
@@ -1456,6 +1927,7 @@ func put(n *Node) {
 
 	n.attrs.reset()
 	n.class.reset()
+	n.style.reset()
 
 	n.writeFn = nil
 
@@ -1671,14 +2143,28 @@ func (am *attrMap) moveSuffixTo(dst *attrMap, suffix string) {
 
 /**/
 
+// ClassResolver classifies class names into conflict groups, see Renderer.ClassMerge.
+// Of the classes with the same variant that belong to a group (or to a group overriding it),
+// only the one added last is rendered.
+type ClassResolver interface {
+	// ClassGroup returns the variant prefix (e.g. "md:hover:") and the conflict group of class.
+	// Classes with an empty group never conflict.
+	ClassGroup(class string) (variant, group string)
+	// ConflictingGroups returns the groups overridden by group, besides group itself.
+	ConflictingGroups(group string) []string
+}
+
 type (
 	classMap struct {
-		o []classEntry
-		m map[string]int
+		o     []classEntry
+		m     map[string]int
+		scope *StyleSheet
+		seq   uint32
 	}
 	classEntry struct {
 		name   string
 		active bool
+		seq    uint32 // the order of activation, the last one wins a class conflict
 	}
 )
 
@@ -1692,17 +2178,19 @@ func newClassMap() *classMap {
 func (cm *classMap) reset() {
 	cm.o = cm.o[:0]
 	clear(cm.m)
+	cm.scope = nil
+	cm.seq = 0
 }
 
 func (cm *classMap) has(name string) bool {
-	if idx, ok := cm.m[name]; ok {
+	if idx, ok := cm.m[cm.scoped(name)]; ok {
 		return cm.o[idx].active
 	}
 	return false
 }
 
 func (cm *classMap) extract(name string) bool {
-	if idx, ok := cm.m[name]; ok && cm.o[idx].active {
+	if idx, ok := cm.m[cm.scoped(name)]; ok && cm.o[idx].active {
 		cm.o[idx].active = false
 		return true
 	}
@@ -1711,7 +2199,7 @@ func (cm *classMap) extract(name string) bool {
 
 func (cm *classMap) hasAny(names ...string) bool {
 	for _, name := range names {
-		if idx, ok := cm.m[name]; ok && cm.o[idx].active {
+		if idx, ok := cm.m[cm.scoped(name)]; ok && cm.o[idx].active {
 			return true
 		}
 	}
@@ -1720,7 +2208,7 @@ func (cm *classMap) hasAny(names ...string) bool {
 
 func (cm *classMap) hasAll(names ...string) bool {
 	for _, name := range names {
-		if idx, ok := cm.m[name]; !ok || !cm.o[idx].active {
+		if idx, ok := cm.m[cm.scoped(name)]; !ok || !cm.o[idx].active {
 			return false
 		}
 	}
@@ -1775,19 +2263,95 @@ func (cm *classMap) setMulti(s string, active bool) {
 	}
 }
 
+func (cm *classMap) scoped(name string) string {
+	if cm.scope != nil {
+		return cm.scope.Class(name)
+	}
+	return name
+}
+
 func (cm *classMap) setOne(name string, active bool) {
+	name = cm.scoped(name)
 	if idx, ok := cm.m[name]; ok {
+		if active {
+			cm.seq++
+			cm.o[idx].seq = cm.seq
+		}
 		cm.o[idx].active = active
 		return
 	}
 	if !active {
 		return
 	}
+	cm.seq++
 	idx := len(cm.o)
-	cm.o = append(cm.o, classEntry{name: name, active: true})
+	cm.o = append(cm.o, classEntry{name: name, active: true, seq: cm.seq})
 	cm.m[name] = idx
 }
 
+// setScope rewrites existing class names to the names scoped by s.
+// Classes added afterwards are rewritten by setOne.
+func (cm *classMap) setScope(s *StyleSheet) {
+	cm.scope = s
+	for i := range cm.o {
+		e := &cm.o[i]
+		scoped := s.Class(e.name)
+		if scoped == e.name {
+			continue
+		}
+		delete(cm.m, e.name)
+		if idx, ok := cm.m[scoped]; ok {
+			cm.o[idx].active = cm.o[idx].active || e.active
+			cm.o[idx].seq = max(cm.o[idx].seq, e.seq)
+			e.active = false
+			continue
+		}
+		e.name = scoped
+		cm.m[scoped] = i
+	}
+}
+
+type classGroup struct {
+	variant, group string
+	overrides      []string
+}
+
+// classGroups classifies the active classes; buf is reused if it is large enough.
+func classGroups(classes []classEntry, res ClassResolver, buf []classGroup) []classGroup {
+	groups := buf[:0]
+	if cap(groups) < len(classes) {
+		groups = make([]classGroup, 0, len(classes))
+	}
+	for _, c := range classes {
+		var g classGroup
+		if c.active {
+			g.variant, g.group = res.ClassGroup(c.name)
+			if g.group != "" {
+				g.overrides = res.ConflictingGroups(g.group)
+			}
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// overridden reports whether the class at i conflicts with a class activated after it.
+func overridden(classes []classEntry, groups []classGroup, i int) bool {
+	g := groups[i]
+	if g.group == "" {
+		return false
+	}
+	for j, c := range classes {
+		if !c.active || c.seq <= classes[i].seq || groups[j].variant != g.variant {
+			continue
+		}
+		if groups[j].group == g.group || slices.Contains(groups[j].overrides, g.group) {
+			return true
+		}
+	}
+	return false
+}
+
 /**/
 
 // ValidTag checks if the string is a valid HTML tag name.
@@ -1831,8 +2395,13 @@ func ValidClass(class string) bool {
 /**/
 
 type (
-	stringptr *byte
-	byteptr   *byte
+	stringptr   *byte
+	byteptr     *byte
+	timeloc     *time.Location
+	dateloc     *time.Location
+	timebox     *time.Time // times outside of the UnixNano range
+	datebox     *time.Time
+	currencyptr *Currency
 )
 
 type ValueKind int
@@ -1847,6 +2416,12 @@ const (
 	KindString
 	KindJSON
 	KindBytes
+	KindTime
+	KindDate
+	KindDuration
+	KindMoney
+	KindLength
+	KindID
 )
 
 // Unset represents an empty, unset or removed value.
@@ -1870,6 +2445,14 @@ func (v TypedValue) Kind() ValueKind {
 		return KindString
 	case byteptr:
 		return KindBytes
+	case timeloc, timebox:
+		return KindTime
+	case dateloc, datebox:
+		return KindDate
+	case currencyptr:
+		return KindMoney
+	case cssUnit:
+		return KindLength
 	default:
 		return ValueKind(v.num)
 	}
@@ -1903,6 +2486,50 @@ func Float(v float64) TypedValue { return TypedValue{num: math.Float64bits(v), a
 func Any(v any) TypedValue       { return TypedValue{any: v, num: uint64(KindAny)} }  // inverse
 func JSON(v any) TypedValue      { return TypedValue{any: v, num: uint64(KindJSON)} } // inverse
 
+// TimeValue holds a point in time. The zero time is treated as Unset.
+// Times between the years 1678 and 2262 are held without allocation.
+func TimeValue(t time.Time) TypedValue {
+	if t.IsZero() {
+		return Unset
+	}
+	if !inUnixNano(t) {
+		return TypedValue{any: timebox(&t)}
+	}
+	return TypedValue{num: uint64(t.UnixNano()), any: timeloc(t.Location())}
+}
+
+// DateValue holds a calendar date; the time of day is ignored when rendering.
+// The zero time is treated as Unset.
+func DateValue(t time.Time) TypedValue {
+	if t.IsZero() {
+		return Unset
+	}
+	if !inUnixNano(t) {
+		return TypedValue{any: datebox(&t)}
+	}
+	return TypedValue{num: uint64(t.UnixNano()), any: dateloc(t.Location())}
+}
+
+var minUnixNano, maxUnixNano = time.Unix(0, math.MinInt64), time.Unix(0, math.MaxInt64)
+
+// inUnixNano reports whether t can be held as Unix nanoseconds.
+func inUnixNano(t time.Time) bool {
+	return !t.Before(minUnixNano) && !t.After(maxUnixNano)
+}
+
+// DurationValue holds a time.Duration.
+func DurationValue(d time.Duration) TypedValue {
+	return TypedValue{num: uint64(d), any: KindDuration}
+}
+
+// Money holds an amount of money in minor units of the currency (e.g. cents).
+func Money(minor int64, c *Currency) TypedValue {
+	if c == nil {
+		return Unset
+	}
+	return TypedValue{num: uint64(minor), any: currencyptr(c)}
+}
+
 func (v TypedValue) String() (string, bool) {
 	if sp, ok := v.any.(stringptr); ok {
 		return unsafe.String(sp, v.num), true
@@ -2020,6 +2647,55 @@ func (v TypedValue) BoolOrZero() bool {
 	return false
 }
 
+// Time returns the time held by a KindTime or KindDate value.
+func (v TypedValue) Time() (time.Time, bool) {
+	switch loc := v.any.(type) {
+	case timeloc:
+		return time.Unix(0, int64(v.num)).In(loc), true
+	case dateloc:
+		return time.Unix(0, int64(v.num)).In(loc), true
+	case timebox:
+		return *loc, true
+	case datebox:
+		return *loc, true
+	}
+	return time.Time{}, false
+}
+
+func (v TypedValue) TimeOrZero() time.Time {
+	t, _ := v.Time()
+	return t
+}
+
+// Duration returns the duration held by a KindDuration value.
+func (v TypedValue) Duration() (time.Duration, bool) {
+	if k, ok := v.any.(ValueKind); ok && k == KindDuration {
+		return time.Duration(v.num), true
+	}
+	return 0, false
+}
+
+func (v TypedValue) DurationOrZero() time.Duration {
+	d, _ := v.Duration()
+	return d
+}
+
+// Money returns the amount in minor units and the currency of a KindMoney value.
+func (v TypedValue) Money() (int64, *Currency, bool) {
+	if c, ok := v.any.(currencyptr); ok {
+		return int64(v.num), c, true
+	}
+	return 0, nil, false
+}
+
+// Length returns the number and the unit of a KindLength value.
+func (v TypedValue) Length() (float64, string, bool) {
+	if u, ok := v.any.(cssUnit); ok {
+		return math.Float64frombits(v.num), u.String(), true
+	}
+	return 0, "", false
+}
+
 func (v TypedValue) JSON() (any, bool) {
 	if v.Kind() == KindJSON {
 		return v.any, true
@@ -2038,8 +2714,14 @@ func (v TypedValue) Any() any {
 	switch k := v.any.(type) {
 	case stringptr:
 		return unsafe.String(k, v.num)
+	case timeloc, dateloc, timebox, datebox:
+		return v.TimeOrZero()
+	case cssUnit:
+		return math.Float64frombits(v.num)
 	case ValueKind:
 		switch k {
+		case KindDuration:
+			return time.Duration(v.num)
 		case KindInt64:
 			return int64(v.num)
 		case KindUint64:
@@ -250,6 +250,39 @@ func Test_Slots_MoveSlotTo(t *testing.T) {
 	}
 }
 
+func Test_Slots_OutletFillAndFallback(t *testing.T) {
+	btn := func(mods ...Mod) *Node {
+		return Button(Class("btn")).Content(
+			SlotOutlet("icon", Text("*")),
+			Span().Content(SlotOutlet("label", Text("OK"))),
+		).Apply(mods)
+	}
+
+	n := Div().Content(
+		btn(Slot("label", Text("Save"))),
+		btn(Slot("icon", I().Class("i-close"))),
+	)
+	defer n.Release()
+
+	want := `<div><button class="btn">*<span>Save</span></button>` +
+		`<button class="btn"><i class="i-close"></i><span>OK</span></button></div>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected second render:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Slots_StrictModeReportsMissingOutlet(t *testing.T) {
+	n := Div().Content(SlotOutlet("a")).Slot("a", Text("1")).Slot("b", Text("2"))
+	defer n.Release()
+
+	if err := (&Renderer{StrictSlots: true}).Render(io.Discard, n); err == nil || !strings.Contains(err.Error(), `"b"`) {
+		t.Fatalf("expected missing outlet error, got %v", err)
+	}
+}
+
 /**/
 
 func Benchmark_Build(b *testing.B) {
@@ -0,0 +1,667 @@
+package htm
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// T creates a translatable text node.
+// The key is resolved at render time against the locale of the render context (see WithLocale).
+// If there is no locale or no translation, the key itself is rendered.
+//
+// Messages may contain placeholders: {0}, {1}, ... refer to args,
+// {name} refers to a node variable set with Var or VarValue.
+// Plural forms are selected by the "count" variable or, if it is not set,
+// by the first numeric argument.
+// The result is HTML-escaped during rendering.
+func T(key string, args ...TypedValue) *Node {
+	if key == "" {
+		return nil
+	}
+	n := Get()
+	n.tag = "$t"
+	n.value = String(key)
+	for i, arg := range args {
+		n.vars = append(n.vars, valueEntry{name: argName(i), value: arg})
+	}
+	n.writeFn = renderT
+	return n
+}
+
+// T sets (replaces) the content of the node to a single translatable text node.
+func (n *Node) T(key string, args ...TypedValue) *Node {
+	return n.Content(T(key, args...))
+}
+
+// TContent returns a Mod that sets (replaces) the content of the node to a single translatable text node.
+func TContent(key string, args ...TypedValue) Mod {
+	return func(n *Node) { n.Content(T(key, args...)) }
+}
+
+var argNames = [...]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+func argName(i int) string {
+	if i < len(argNames) {
+		return argNames[i]
+	}
+	return strconv.Itoa(i)
+}
+
+func renderT(n *Node, w io.Writer) error {
+	key, _ := n.value.String()
+	msg := key
+	if l := LocaleOf(w); l != nil {
+		if m, ok := l.Lookup(key); ok {
+			msg = m.Form(l.plural(pluralOperand(n.vars)))
+		}
+	}
+	return writeMessage(w, msg, n.vars)
+}
+
+func pluralOperand(vars []valueEntry) float64 {
+	for _, v := range vars {
+		if v.name == "count" {
+			if f, ok := numericValue(v.value); ok {
+				return f
+			}
+			break
+		}
+	}
+	for _, v := range vars {
+		if len(v.name) > 0 && v.name[0] >= '0' && v.name[0] <= '9' {
+			if f, ok := numericValue(v.value); ok {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+func numericValue(v TypedValue) (float64, bool) {
+	switch v.Kind() {
+	case KindInt64:
+		return float64(int64(v.num)), true
+	case KindUint64:
+		return float64(v.num), true
+	case KindFloat64:
+		return math.Float64frombits(v.num), true
+	}
+	return 0, false
+}
+
+// writeMessage writes msg HTML-escaped, substituting {name} placeholders with variables.
+// Unknown placeholders are written as is.
+func writeMessage(w io.Writer, msg string, vars []valueEntry) error {
+	esc := EscapeWriter(w.Write)
+	for len(msg) > 0 {
+		i := strings.IndexByte(msg, '{')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(msg[i:], '}')
+		if j < 0 {
+			break
+		}
+		name := msg[i+1 : i+j]
+		found := false
+		for _, v := range vars {
+			if v.name == name && v.value.Valid() {
+				if _, err := WriteString(esc, msg[:i]); err != nil {
+					return err
+				}
+				if err := writeText(w, v.value); err != nil {
+					return err
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			if _, err := WriteString(esc, msg[:i+j+1]); err != nil {
+				return err
+			}
+		}
+		msg = msg[i+j+1:]
+	}
+	if len(msg) > 0 {
+		_, err := WriteString(esc, msg)
+		return err
+	}
+	return nil
+}
+
+/**/
+
+// Locale describes a language used for rendering.
+type Locale struct {
+	// Tag is a BCP 47 language tag, e.g. "en-US".
+	Tag string
+	// Lang is the base language of Tag, e.g. "en".
+	Lang string
+	// Dir is the text direction: "ltr" or "rtl".
+	Dir string
+	// Catalog provides translated messages.
+	Catalog Catalog
+	// Plural selects a plural category for a number.
+	Plural PluralRule
+
+	// Decimal is the decimal separator.
+	Decimal string
+	// Group is the digit grouping separator.
+	Group string
+	// GroupMin is the minimum number of digits in the leading group
+	// required to apply grouping (e.g. 2 means 1234 is not grouped but 12345 is).
+	GroupMin int
+	// DateLayout is a Go time layout for dates.
+	DateLayout string
+	// DateTimeLayout is a Go time layout for date and time.
+	DateTimeLayout string
+	// CurrencyPattern describes money formatting: "#" is the amount, "¤" is the currency symbol.
+	CurrencyPattern string
+}
+
+// NewLocale creates a locale for the given language tag.
+// Text direction, plural rules and number and date formats are derived from the tag.
+func NewLocale(tag string, catalog Catalog) *Locale {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	lang, _, _ := strings.Cut(tag, "-")
+	lang = strings.ToLower(lang)
+	dir := "ltr"
+	switch lang {
+	case "ar", "he", "fa", "ur", "ps", "yi", "dv", "ug", "ckb", "sd":
+		dir = "rtl"
+	}
+	l := &Locale{
+		Tag:     tag,
+		Lang:    lang,
+		Dir:     dir,
+		Catalog: catalog,
+		Plural:  PluralRuleFor(lang),
+	}
+	l.applyFormat()
+	return l
+}
+
+// Lookup finds the message for key, trying the full tag first and then the base language.
+func (l *Locale) Lookup(key string) (Message, bool) {
+	if l.Catalog == nil {
+		return Message{}, false
+	}
+	if m, ok := l.Catalog.Lookup(l.Tag, key); ok {
+		return m, true
+	}
+	if l.Lang != l.Tag {
+		return l.Catalog.Lookup(l.Lang, key)
+	}
+	return Message{}, false
+}
+
+func (l *Locale) plural(n float64) PluralCategory {
+	if l.Plural == nil {
+		return PluralOther
+	}
+	return l.Plural(n)
+}
+
+/**/
+
+// Message is a translated message with optional plural forms.
+type Message struct {
+	Zero, One, Two, Few, Many, Other string
+}
+
+// Form returns the text for the plural category, falling back to Other.
+func (m Message) Form(c PluralCategory) string {
+	var s string
+	switch c {
+	case PluralZero:
+		s = m.Zero
+	case PluralOne:
+		s = m.One
+	case PluralTwo:
+		s = m.Two
+	case PluralFew:
+		s = m.Few
+	case PluralMany:
+		s = m.Many
+	}
+	if s == "" {
+		return m.Other
+	}
+	return s
+}
+
+func (m *Message) set(c PluralCategory, s string) {
+	switch c {
+	case PluralZero:
+		m.Zero = s
+	case PluralOne:
+		m.One = s
+	case PluralTwo:
+		m.Two = s
+	case PluralFew:
+		m.Few = s
+	case PluralMany:
+		m.Many = s
+	default:
+		m.Other = s
+	}
+}
+
+// Catalog provides translated messages.
+type Catalog interface {
+	// Lookup returns the message for key in the given language.
+	Lookup(lang, key string) (Message, bool)
+}
+
+// MapCatalog is a Catalog backed by Go maps: language -> key -> message.
+type MapCatalog map[string]map[string]Message
+
+// Lookup implements Catalog.
+func (mc MapCatalog) Lookup(lang, key string) (Message, bool) {
+	m, ok := mc[lang][key]
+	return m, ok
+}
+
+// Merge copies all messages from src into mc, overwriting existing ones.
+func (mc MapCatalog) Merge(src MapCatalog) MapCatalog {
+	for lang, msgs := range src {
+		dst := mc[lang]
+		if dst == nil {
+			dst = make(map[string]Message, len(msgs))
+			mc[lang] = dst
+		}
+		for k, m := range msgs {
+			dst[k] = m
+		}
+	}
+	return mc
+}
+
+// Catalogs combines multiple catalogs; the first one containing the message wins.
+type Catalogs []Catalog
+
+// Lookup implements Catalog.
+func (cs Catalogs) Lookup(lang, key string) (Message, bool) {
+	for _, c := range cs {
+		if m, ok := c.Lookup(lang, key); ok {
+			return m, true
+		}
+	}
+	return Message{}, false
+}
+
+// LoadJSONCatalog loads message files matching pattern from fsys.
+// The language is taken from the file name (e.g. "locales/de.json" is "de").
+// Each file contains an object of keys to either a string or
+// an object of plural forms ("zero", "one", "two", "few", "many", "other").
+func LoadJSONCatalog(fsys fs.FS, pattern string) (MapCatalog, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	mc := make(MapCatalog, len(files))
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var raw map[string]json.RawMessage
+		if err = json.Unmarshal(b, &raw); err != nil {
+			return nil, fmt.Errorf("htm: %v: %w", file, err)
+		}
+		msgs := make(map[string]Message, len(raw))
+		for key, v := range raw {
+			var s string
+			if json.Unmarshal(v, &s) == nil {
+				msgs[key] = Message{Other: s}
+				continue
+			}
+			var forms map[string]string
+			if err = json.Unmarshal(v, &forms); err != nil {
+				return nil, fmt.Errorf("htm: %v: key %q: %w", file, key, err)
+			}
+			var m Message
+			for name, text := range forms {
+				c, ok := ParsePluralCategory(name)
+				if !ok {
+					return nil, fmt.Errorf("htm: %v: key %q: unknown plural category %q", file, key, name)
+				}
+				m.set(c, text)
+			}
+			msgs[key] = m
+		}
+		mc.Merge(MapCatalog{langFromFile(file): msgs})
+	}
+	return mc, nil
+}
+
+// LoadPOCatalog loads gettext PO files matching pattern from fsys.
+// The language is taken from the file name (e.g. "locales/de.po" is "de").
+// Fuzzy and untranslated entries are skipped. Plural translations (msgstr[N])
+// are mapped to the CLDR categories of the language in their usual gettext order.
+// Entries with a context (msgctxt) are keyed by the context and the msgid joined by "\x04",
+// as gettext does, e.g. T("menu\x04Open").
+func LoadPOCatalog(fsys fs.FS, pattern string) (MapCatalog, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	mc := make(MapCatalog, len(files))
+	for _, file := range files {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		lang := langFromFile(file)
+		msgs, err := parsePO(f, pluralCategoriesFor(lang))
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("htm: %v: %w", file, err)
+		}
+		mc.Merge(MapCatalog{lang: msgs})
+	}
+	return mc, nil
+}
+
+func langFromFile(file string) string {
+	base := path.Base(file)
+	return strings.ReplaceAll(strings.TrimSuffix(base, path.Ext(base)), "_", "-")
+}
+
+func parsePO(r io.Reader, categories []PluralCategory) (map[string]Message, error) {
+	msgs := make(map[string]Message)
+
+	var (
+		ctxt   string
+		id     string
+		strs   []string
+		fuzzy  bool
+		target *string
+		line   int
+	)
+	flush := func() {
+		if id != "" && !fuzzy {
+			var m Message
+			ok := false
+			for i, s := range strs {
+				if s == "" {
+					continue
+				}
+				c := PluralOther
+				if len(strs) > 1 && i < len(categories) {
+					c = categories[i]
+				}
+				m.set(c, s)
+				ok = true
+			}
+			if ok {
+				if ctxt != "" {
+					msgs[ctxt+"\x04"+id] = m
+				} else {
+					msgs[id] = m
+				}
+			}
+		}
+		ctxt, id, strs, fuzzy, target = "", "", nil, false, nil
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line++
+		s := strings.TrimSpace(sc.Text())
+		switch {
+		case s == "":
+			flush()
+			continue
+		case strings.HasPrefix(s, "#,"):
+			if strings.Contains(s, "fuzzy") {
+				fuzzy = true
+			}
+			continue
+		case s[0] == '#':
+			continue
+		case s[0] == '"':
+			if target == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", line)
+			}
+			v, err := strconv.Unquote(s)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			*target += v
+			continue
+		}
+
+		kw, rest, _ := strings.Cut(s, " ")
+		v, err := strconv.Unquote(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		switch {
+		case kw == "msgctxt":
+			if len(strs) > 0 {
+				flush()
+			}
+			ctxt = v
+			target = &ctxt
+		case kw == "msgid":
+			if len(strs) > 0 {
+				flush()
+			}
+			id = v
+			target = &id
+		case kw == "msgid_plural":
+			target = nil
+		case kw == "msgstr":
+			strs = append(strs[:0], v)
+			target = &strs[0]
+		case strings.HasPrefix(kw, "msgstr["):
+			strs = append(strs, v)
+			target = &strs[len(strs)-1]
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %q", line, kw)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return msgs, nil
+}
+
+/**/
+
+// PluralCategory is a CLDR plural category.
+type PluralCategory uint8
+
+const (
+	PluralOther PluralCategory = iota
+	PluralZero
+	PluralOne
+	PluralTwo
+	PluralFew
+	PluralMany
+)
+
+// ParsePluralCategory parses a CLDR plural category name ("zero", "one", "two", "few", "many", "other").
+func ParsePluralCategory(s string) (PluralCategory, bool) {
+	switch s {
+	case "other":
+		return PluralOther, true
+	case "zero":
+		return PluralZero, true
+	case "one":
+		return PluralOne, true
+	case "two":
+		return PluralTwo, true
+	case "few":
+		return PluralFew, true
+	case "many":
+		return PluralMany, true
+	}
+	return PluralOther, false
+}
+
+// PluralRule selects a plural category for a number.
+type PluralRule func(n float64) PluralCategory
+
+// PluralRuleFor returns the CLDR cardinal plural rule for a base language.
+// Unknown languages use the English rule.
+func PluralRuleFor(lang string) PluralRule {
+	switch lang {
+	case "ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my", "km":
+		return pluralNone
+	case "fr", "pt":
+		return pluralZeroOne
+	case "hi", "bn", "fa", "am", "zu":
+		return pluralZeroOrOne
+	case "ru", "uk", "be":
+		return pluralEastSlavic
+	case "pl":
+		return pluralPolish
+	case "cs", "sk":
+		return pluralCzech
+	case "ar":
+		return pluralArabic
+	case "he":
+		return pluralHebrew
+	default:
+		return pluralEnglish
+	}
+}
+
+func pluralCategoriesFor(lang string) []PluralCategory {
+	switch lang {
+	case "ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my", "km":
+		return []PluralCategory{PluralOther}
+	case "ru", "uk", "be", "pl":
+		return []PluralCategory{PluralOne, PluralFew, PluralMany}
+	case "cs", "sk":
+		return []PluralCategory{PluralOne, PluralFew, PluralOther}
+	case "ar":
+		return []PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+	case "he":
+		return []PluralCategory{PluralOne, PluralTwo, PluralOther}
+	default:
+		return []PluralCategory{PluralOne, PluralOther}
+	}
+}
+
+// pluralOperands returns the absolute integer part of n and whether n has a fraction.
+func pluralOperands(n float64) (i uint64, frac bool) {
+	n = math.Abs(n)
+	t := math.Trunc(n)
+	return uint64(t), n != t
+}
+
+func pluralNone(float64) PluralCategory { return PluralOther }
+
+func pluralEnglish(n float64) PluralCategory {
+	if i, frac := pluralOperands(n); i == 1 && !frac {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralZeroOne(n float64) PluralCategory {
+	if i, _ := pluralOperands(n); i <= 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralZeroOrOne is "i = 0 or n = 1": unlike pluralZeroOne, 1.5 is other.
+func pluralZeroOrOne(n float64) PluralCategory {
+	if i, frac := pluralOperands(n); i == 0 || i == 1 && !frac {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralEastSlavic(n float64) PluralCategory {
+	i, frac := pluralOperands(n)
+	if frac {
+		return PluralOther
+	}
+	m10, m100 := i%10, i%100
+	switch {
+	case m10 == 1 && m100 != 11:
+		return PluralOne
+	case m10 >= 2 && m10 <= 4 && (m100 < 12 || m100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralPolish(n float64) PluralCategory {
+	i, frac := pluralOperands(n)
+	if frac {
+		return PluralOther
+	}
+	m10, m100 := i%10, i%100
+	switch {
+	case i == 1:
+		return PluralOne
+	case m10 >= 2 && m10 <= 4 && (m100 < 12 || m100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralCzech(n float64) PluralCategory {
+	i, frac := pluralOperands(n)
+	switch {
+	case frac:
+		return PluralMany
+	case i == 1:
+		return PluralOne
+	case i >= 2 && i <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralArabic(n float64) PluralCategory {
+	i, frac := pluralOperands(n)
+	if frac {
+		return PluralOther
+	}
+	m100 := i % 100
+	switch {
+	case i == 0:
+		return PluralZero
+	case i == 1:
+		return PluralOne
+	case i == 2:
+		return PluralTwo
+	case m100 >= 3 && m100 <= 10:
+		return PluralFew
+	case m100 >= 11:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+func pluralHebrew(n float64) PluralCategory {
+	i, frac := pluralOperands(n)
+	switch {
+	case i == 1 && !frac, i == 0 && frac:
+		return PluralOne
+	case i == 2 && !frac:
+		return PluralTwo
+	default:
+		return PluralOther
+	}
+}
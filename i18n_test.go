@@ -0,0 +1,152 @@
+package htm
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func renderWith(t *testing.T, n *Node, ctx *Context) string {
+	t.Helper()
+	var b strings.Builder
+	if err := n.Render(WithContext(&b, ctx)); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func Test_T_ResolvesAgainstLocale(t *testing.T) {
+	catalog := MapCatalog{
+		"de": {"Save": {Other: "Speichern"}},
+		"ru": {"{0} files": {One: "{0} файл", Few: "{0} файла", Many: "{0} файлов"}},
+	}
+
+	n := Button().T("Save")
+	defer n.Release()
+
+	if got := n.String(); got != `<button>Save</button>` {
+		t.Fatalf("expected key without locale, got %q", got)
+	}
+	if got := renderWith(t, n, &Context{Locale: NewLocale("de-AT", catalog)}); got != `<button>Speichern</button>` {
+		t.Fatalf("expected base language fallback, got %q", got)
+	}
+
+	ru := NewLocale("ru", catalog)
+	for count, want := range map[int]string{1: "1 файл", 3: "3 файла", 11: "11 файлов", 21: "21 файл"} {
+		x := T("{0} files", Int(count))
+		if got := renderWith(t, x, &Context{Locale: ru}); got != want {
+			t.Fatalf("count %d: expected %q, got %q", count, want, got)
+		}
+		x.Release()
+	}
+}
+
+func Test_T_NamedPlaceholdersEscaped(t *testing.T) {
+	n := T("Hello, {name}! {missing}").Var("name", "<b>")
+	defer n.Release()
+
+	if got := n.String(); got != `Hello, &lt;b&gt;! {missing}` {
+		t.Fatalf("unexpected: %q", got)
+	}
+}
+
+func Test_Html_LocaleLangDir(t *testing.T) {
+	n := Html()
+	defer n.Release()
+
+	if got := renderWith(t, n, &Context{Locale: NewLocale("ar_EG", nil)}); got != `<html lang="ar-EG" dir="rtl"></html>` {
+		t.Fatalf("unexpected: %q", got)
+	}
+	n.Lang("en")
+	if got := renderWith(t, n, &Context{Locale: NewLocale("ar", nil)}); got != `<html lang="en" dir="rtl"></html>` {
+		t.Fatalf("unexpected: %q", got)
+	}
+}
+
+func Test_LoadCatalogs_JSONAndPO(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/de.json": {Data: []byte(`{"Save": "Speichern", "items": {"one": "ein Element", "other": "{0} Elemente"}}`)},
+		"locales/pl.po": {Data: []byte(`
+msgid ""
+msgstr ""
+"Language: pl\n"
+
+#, fuzzy
+msgid "Cancel"
+msgstr "Anuluj"
+
+msgid "Save"
+msgstr "Zapisz"
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Otwórz"
+
+msgctxt "state"
+msgid "Open"
+msgstr "Otwarte"
+
+msgid "one file"
+msgid_plural "{0} files"
+msgstr[0] "{0} plik"
+msgstr[1] "{0} pliki"
+msgstr[2] ""
+"{0} plików"
+`)},
+	}
+
+	js, err := LoadJSONCatalog(fsys, "locales/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	po, err := LoadPOCatalog(fsys, "locales/*.po")
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog := Catalogs{js, po}
+
+	if m, ok := catalog.Lookup("de", "items"); !ok || m.Form(PluralOne) != "ein Element" || m.Form(PluralMany) != "{0} Elemente" {
+		t.Fatalf("unexpected json message: %+v", m)
+	}
+	if _, ok := catalog.Lookup("pl", "Cancel"); ok {
+		t.Fatalf("expected fuzzy entry to be skipped")
+	}
+	if m, _ := catalog.Lookup("pl", "Save"); m.Other != "Zapisz" {
+		t.Fatalf("unexpected po message: %+v", m)
+	}
+
+	if m, _ := catalog.Lookup("pl", "menu\x04Open"); m.Other != "Otwórz" {
+		t.Fatalf("unexpected po message with context: %+v", m)
+	}
+	if m, _ := catalog.Lookup("pl", "state\x04Open"); m.Other != "Otwarte" {
+		t.Fatalf("unexpected po message with context: %+v", m)
+	}
+	if _, ok := catalog.Lookup("pl", "Open"); ok {
+		t.Fatalf("expected entries with context to be keyed by it")
+	}
+
+	pl := &Context{Locale: NewLocale("pl", catalog)}
+	n := T("one file", Int(5))
+	defer n.Release()
+	if got := renderWith(t, n, pl); got != "5 plików" {
+		t.Fatalf("unexpected po plural: %q", got)
+	}
+}
+
+func Test_PluralRuleFor(t *testing.T) {
+	cases := []struct {
+		lang string
+		n    float64
+		want PluralCategory
+	}{
+		{"en", 1, PluralOne}, {"en", 1.5, PluralOther}, {"en", 0, PluralOther},
+		{"fr", 0, PluralOne}, {"fr", 1.5, PluralOne}, {"fr", 2, PluralOther},
+		{"hi", 0, PluralOne}, {"hi", 0.5, PluralOne}, {"hi", 1, PluralOne}, {"hi", 1.5, PluralOther}, {"hi", 2, PluralOther},
+		{"ru", 21, PluralOne}, {"ru", 22, PluralFew}, {"ru", 25, PluralMany},
+	}
+	for _, c := range cases {
+		if got := PluralRuleFor(c.lang)(c.n); got != c.want {
+			t.Fatalf("%s %v: got %d, want %d", c.lang, c.n, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package htm
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// IDGenerator generates unique element IDs. It is safe for concurrent use.
+type IDGenerator struct {
+	Prefix string
+	n      atomic.Uint64
+
+	mu   sync.Mutex
+	keys map[string]uint64 // nil for process-wide generators
+}
+
+// NewIDGenerator returns a generator with its own sequence, meant to be used for one render,
+// so the generated IDs are the same each time a tree is rendered.
+// Renderer creates one for each call to Render; see also Context.IDs.
+func NewIDGenerator(prefix string) *IDGenerator {
+	return &IDGenerator{Prefix: prefix, keys: make(map[string]uint64)}
+}
+
+// Next returns the next ID of the sequence.
+func (g *IDGenerator) Next() string { return g.Prefix + strconv.FormatUint(g.n.Add(1), 10) }
+
+// Key returns an ID derived from key, e.g. "id-email" for the key "email".
+// Repeated keys get a numeric suffix: "id-email-2", "id-email-3" and so on.
+// A generator not created by NewIDGenerator, such as DefaultIDs, always adds a sequence number
+// to not keep track of keys for the life of the process.
+func (g *IDGenerator) Key(key string) string {
+	if key == "" {
+		return g.Next()
+	}
+	if g.keys == nil {
+		return g.Prefix + key + "-" + strconv.FormatUint(g.n.Add(1), 10)
+	}
+	g.mu.Lock()
+	g.keys[key]++
+	seq := g.keys[key]
+	g.mu.Unlock()
+	if seq == 1 {
+		return g.Prefix + key
+	}
+	return g.Prefix + key + "-" + strconv.FormatUint(seq, 10)
+}
+
+// DefaultIDs is the process-wide generator used by UniqueID and when the render context has no IDs.
+var DefaultIDs = &IDGenerator{Prefix: "id-"}
+
+// UniqueID returns the next ID from DefaultIDs.
+// The IDs differ between renders; use NewID for IDs assigned at render time.
+func UniqueID() string { return DefaultIDs.Next() }
+
+// IDsOf returns the ID generator of the render context carried by w, or DefaultIDs.
+func IDsOf(w io.Writer) *IDGenerator {
+	if ctx := ContextOf(w); ctx != nil && ctx.IDs != nil {
+		return ctx.IDs
+	}
+	return DefaultIDs
+}
+
+// ElementID is an element ID assigned when it is first rendered, by the generator of the render context.
+// All references to the same ID within a render get the same value, and rendering a tree
+// again with a new generator gives the same IDs in the same order.
+//
+//	id := htm.NewID("email")
+//	htm.Label(htm.ForValue(id.Value())).Text("Email")
+//	htm.Input(htm.AttrValue("id", id.Value()), htm.Name("email"))
+//
+// An ElementID must not be rendered concurrently by several renders.
+type ElementID struct {
+	key   string
+	gen   *IDGenerator
+	value string
+}
+
+// NewID returns an ID generated from key (see IDGenerator.Key), or from the sequence if key is empty.
+func NewID(key string) *ElementID { return &ElementID{key: key} }
+
+// Value returns a KindID value that renders as the ID.
+func (id *ElementID) Value() TypedValue { return TypedValue{num: uint64(KindID), any: id} }
+
+// resolve returns the value of the ID assigned by the generator of w.
+func (id *ElementID) resolve(w io.Writer) string {
+	if g := IDsOf(w); id.gen != g {
+		id.gen = g
+		id.value = g.Key(id.key)
+	}
+	return id.value
+}
+
+type idList []TypedValue
+
+// IDList returns a KindID value that renders as a space-separated list of IDs,
+// as used by aria-describedby or aria-labelledby. Values may be IDs or strings; unset values are skipped.
+func IDList(values ...TypedValue) TypedValue {
+	return TypedValue{num: uint64(KindID), any: idList(values)}
+}
+
+// writeID writes the HTML-escaped KindID value v.
+func writeID(w io.Writer, v TypedValue) error {
+	switch id := v.any.(type) {
+	case *ElementID:
+		_, err := WriteString(EscapeWriter(w.Write), id.resolve(w))
+		return err
+	case idList:
+		first := true
+		for _, v := range id {
+			if !v.Valid() {
+				continue
+			}
+			if !first {
+				if _, err := w.Write(space); err != nil {
+					return err
+				}
+			}
+			first = false
+			if v.Kind() == KindID {
+				if err := writeID(w, v); err != nil {
+					return err
+				}
+			} else if _, err := WriteString(EscapeWriter(w.Write), v.StringOrZero()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
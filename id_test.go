@@ -0,0 +1,79 @@
+package htm
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_ID_DeterministicAcrossRenders(t *testing.T) {
+	email := NewID("email")
+
+	input := Input().Name("name")
+	form := Form().Content(
+		Label(ForValue(email.Value())).Text("Email"),
+		Input(AttrValue("id", email.Value())),
+		Label().ForValue(input.IDRef()).Text("Name"),
+		input,
+		Span(AttrValue("aria-describedby", IDList(String("hint"), email.Value(), Unset))),
+		Span().ID("x").AutoID(),
+		Div().Attr("id", "fixed"),
+	)
+	defer form.Release()
+
+	want := `<form><label for="id-email">Email</label><input id="id-email"/>` +
+		`<label for="id-1">Name</label><input name="name" id="id-1"/>` +
+		`<span aria-describedby="hint id-email"></span><span id="id-2"></span><div id="fixed"></div></form>`
+
+	r := new(Renderer)
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		if err := r.Render(&buf, form); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+		}
+	}
+}
+
+func Test_ID_KeysAndContext(t *testing.T) {
+	g := NewIDGenerator("f-")
+	if a, b, c := g.Key("name"), g.Key("name"), g.Key(""); a != "f-name" || b != "f-name-2" || c != "f-1" {
+		t.Fatalf("unexpected keys: %s %s %s", a, b, c)
+	}
+
+	a, b := NewID("name"), NewID("name")
+	n := Div(AttrValue("id", a.Value())).Content(Span(AttrValue("id", b.Value())))
+	defer n.Release()
+
+	var buf bytes.Buffer
+	if err := n.Render(WithContext(&buf, &Context{IDs: NewIDGenerator("x-")})); err != nil {
+		t.Fatal(err)
+	}
+	want := `<div id="x-name"><span id="x-name-2"></span></div>`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	buf.Reset()
+	if err := (&Renderer{IDPrefix: "p-"}).Render(&buf, n); err != nil {
+		t.Fatal(err)
+	}
+	want = `<div id="p-name"><span id="p-name-2"></span></div>`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_ID_UniqueIDIsString(t *testing.T) {
+	n := Span().UniqueID()
+	defer n.Release()
+
+	id := n.GetAttr("id").StringOrZero()
+	if id == "" || n.GetVar("htm_unique_id").StringOrZero() != id {
+		t.Fatalf("unexpected id: %q", id)
+	}
+	if got, want := n.String(), `<span id="`+id+`"></span>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,232 @@
+// Command htmgen generates the element and attribute helpers of htm, and the attribute
+// helpers of the aria, hx and ax packages, from the tables in the spec directory.
+//
+// It is run from the module root by go generate:
+//
+//	go generate .
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const specDir = "internal/htmgen/spec"
+
+// packages lists the sub-packages with generated helpers.
+var packages = []struct {
+	spec, out string
+	setDoc    string // doc of Set functions; empty repeats the doc of the Mod function
+}{
+	{spec: "aria.txt", out: "aria/aria_gen.go"},
+	{spec: "hx.txt", out: "hx/htmx_gen.go", setDoc: "sets the %s attribute on the node."},
+	{spec: "ax.txt", out: "ax/alpine_gen.go", setDoc: "sets the %s attribute on the node."},
+}
+
+func main() {
+	root := flag.String("root", ".", "module root")
+	flag.Parse()
+
+	files, err := generate(*root)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, src := range files {
+		if err = os.WriteFile(filepath.Join(*root, name), src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the generated files by path relative to the module root.
+func generate(root string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	items, enums, err := parseHTML(filepath.Join(root, specDir, "html.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if files["tags_gen.go"], err = genTags(items); err != nil {
+		return nil, err
+	}
+	if files["attrs_gen.go"], err = genAttrs(items, enums); err != nil {
+		return nil, err
+	}
+
+	for _, p := range packages {
+		items, err := parseHelpers(filepath.Join(root, specDir, p.spec))
+		if err != nil {
+			return nil, err
+		}
+		if files[p.out], err = genHelpers(path.Dir(p.out), p.spec, p.setDoc, items); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func header(b *bytes.Buffer, spec, pkg string) {
+	fmt.Fprintf(b, "// Code generated by htmgen from %s/%s. DO NOT EDIT.\n\n", specDir, spec)
+	fmt.Fprintf(b, "package %s\n\n", pkg)
+}
+
+func genTags(items []item) ([]byte, error) {
+	var b bytes.Buffer
+	header(&b, "html.txt", "htm")
+	var names []string
+	for _, it := range items {
+		if it.tag == nil {
+			continue
+		}
+		names = append(names, it.tag.name)
+		if it.tag.fn != "-" {
+			fmt.Fprintf(&b, "func %s(m ...Mod) *Node { return Build(%q, m...) }\n", it.tag.fn, it.tag.name)
+		}
+	}
+	slices.Sort(names)
+	b.WriteString("\n// htmlElements is the set of standard HTML elements.\nvar htmlElements = map[string]struct{}{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "%q: {},\n", name)
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+func genAttrs(items []item, enums []*enum) ([]byte, error) {
+	var b bytes.Buffer
+	header(&b, "html.txt", "htm")
+
+	for _, e := range enums {
+		if len(e.attrs) == 0 {
+			return nil, fmt.Errorf("enum %s is not used by any attribute", e.typ)
+		}
+		fmt.Fprintf(&b, "// %s is a keyword of the %s %s.\n", e.typ, strings.Join(e.attrs, " and "), plural("attribute", len(e.attrs)))
+		fmt.Fprintf(&b, "type %s string\n\nconst (\n", e.typ)
+		for i, name := range e.names {
+			fmt.Fprintf(&b, "%s%s %s = %q\n", e.prefix, name, e.typ, e.values[i])
+		}
+		b.WriteString(")\n\n")
+	}
+
+	elements := make(map[string][]string)
+	for _, it := range items {
+		switch a := it.attr; {
+		case it.line != "":
+			fmt.Fprintf(&b, "%s\n\n", it.line)
+		case a != nil:
+			if els, ok := elements[a.name]; !ok || els != nil {
+				if a.elements == nil {
+					elements[a.name] = nil
+				} else {
+					elements[a.name] = append(els, a.elements...)
+				}
+			}
+			if a.fn == "" {
+				continue
+			}
+			typ, fn, arg := call(a.typ, a.param, "", false)
+			fmt.Fprintf(&b, "func %s(%s %s) Mod { return %s(%q, %s) }\n", a.fn, a.param, typ, fn, a.name, arg)
+			fmt.Fprintf(&b, "func (n *Node) %s(%s %s) *Node { return n.%s(%q, %s) }\n\n", a.method, a.param, typ, fn, a.name, arg)
+		}
+	}
+
+	b.WriteString("// attrElements maps attribute names to the elements they are allowed on; nil means any element.\n")
+	b.WriteString("var attrElements = map[string][]string{\n")
+	names := make([]string, 0, len(elements))
+	for name := range elements {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		els := elements[name]
+		if els == nil {
+			fmt.Fprintf(&b, "%q: nil,\n", name)
+			continue
+		}
+		slices.Sort(els)
+		els = slices.Compact(els)
+		fmt.Fprintf(&b, "%q: {%q", name, els[0])
+		for _, el := range els[1:] {
+			fmt.Fprintf(&b, ", %q", el)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+func genHelpers(pkg, spec, setDoc string, items []item) ([]byte, error) {
+	var b bytes.Buffer
+	header(&b, spec, pkg)
+	b.WriteString("import \"github.com/vapstack/htm\"\n\n")
+	for _, it := range items {
+		h := it.helper
+		if h == nil {
+			fmt.Fprintf(&b, "%s\n\n", it.line)
+			continue
+		}
+		typ, fn, arg := call(h.typ, h.param, "htm.", true)
+		doc(&b, h.fn, h.doc)
+		fmt.Fprintf(&b, "func %s(%s %s) htm.Mod {\n\treturn htm.%s(%q, %s)\n}\n\n", h.fn, h.param, typ, fn, h.name, arg)
+		if setDoc == "" {
+			doc(&b, "Set"+h.fn, h.doc)
+		} else {
+			doc(&b, "Set"+h.fn, []string{fmt.Sprintf(setDoc, h.name)})
+		}
+		fmt.Fprintf(&b, "func Set%s(n *htm.Node, %s %s) {\n\tn.%s(%q, %s)\n}\n\n", h.fn, h.param, typ, fn, h.name, arg)
+	}
+	return format.Source(b.Bytes())
+}
+
+func doc(b *bytes.Buffer, fn string, lines []string) {
+	fmt.Fprintf(b, "// %s %s\n", fn, lines[0])
+	for _, l := range lines[1:] {
+		fmt.Fprintf(b, "// %s\n", l)
+	}
+}
+
+// call returns the parameter type, the setter and its argument for a parameter p of type typ.
+// Boolean attributes of htm take TypedValue arguments, those of sub-packages take bools.
+// Other types are enums.
+func call(typ, p, qual string, boolArgs bool) (paramType, fn, arg string) {
+	switch typ {
+	case "string":
+		return "string", "Attr", p
+	case "int":
+		return "int", "AttrValue", qual + "Int(" + p + ")"
+	case "float":
+		return "float64", "AttrValue", qual + "Float(" + p + ")"
+	case "value":
+		return qual + "TypedValue", "AttrValue", p
+	case "bool":
+		if boolArgs {
+			return "...bool", "AttrBool", p + "..."
+		}
+		return "..." + qual + "TypedValue", "AttrValue", p + "..."
+	}
+	return typ, "Attr", "string(" + p + ")"
+}
+
+func plural(s string, n int) string {
+	if n == 1 {
+		return s
+	}
+	return s + "s"
+}
+
+// camel converts an attribute value like "until-found" to "UntilFound".
+func camel(s string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' || r == '/' }) {
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
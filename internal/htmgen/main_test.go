@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Generate_UpToDate(t *testing.T) {
+	files, err := generate("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join("../..", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate", name)
+		}
+	}
+}
+
+func Test_Generate_Camel(t *testing.T) {
+	for s, want := range map[string]string{"auto": "Auto", "until-found": "UntilFound", "current-password": "CurrentPassword"} {
+		if got := camel(s); got != want {
+			t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+		}
+	}
+}
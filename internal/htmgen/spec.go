@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// item is a line of a spec: a tag, an attribute, a helper or a verbatim line.
+type item struct {
+	line   string // "// comment" or "/**/"
+	tag    *tag
+	attr   *attr
+	helper *helper
+}
+
+type tag struct {
+	name, fn string
+}
+
+type attr struct {
+	name, fn, method string   // fn is empty if the helpers are hand-written
+	param, typ       string   // typ is a basic type or the name of an enum
+	elements         []string // nil for global attributes
+}
+
+// enum is a string type with a constant for each keyword of an attribute.
+type enum struct {
+	typ, prefix string
+	names       []string // names of the constants, without the prefix
+	values      []string
+	attrs       []string // attributes of the type, for the doc
+}
+
+type helper struct {
+	name, fn   string
+	param, typ string
+	doc        []string // without the leading function name
+}
+
+// readLines returns the lines of a spec file without comments and blank lines.
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, sc.Err()
+}
+
+// parseHTML reads the table of HTML elements, enums and attributes.
+func parseHTML(path string) ([]item, []*enum, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []item
+	var enums []*enum
+	byType := make(map[string]*enum)
+	section := ""
+	for i, line := range lines {
+		errorf := func(format string, args ...any) error {
+			return fmt.Errorf("%s: %q: %s", path, lines[i], fmt.Sprintf(format, args...))
+		}
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+		if line == "/**/" || strings.HasPrefix(line, "//") {
+			items = append(items, item{line: line})
+			continue
+		}
+		f := strings.Fields(line)
+		switch section {
+		case "tags":
+			if len(f) != 2 {
+				return nil, nil, errorf("want name and function")
+			}
+			items = append(items, item{tag: &tag{name: f[0], fn: f[1]}})
+		case "enums":
+			var e *enum
+			if f[0] == "+" && len(enums) > 0 {
+				e, f = enums[len(enums)-1], f[1:]
+			} else if len(f) < 3 {
+				return nil, nil, errorf("want type, prefix and values")
+			} else {
+				e, f = &enum{typ: f[0], prefix: f[1]}, f[2:]
+				enums = append(enums, e)
+				byType[e.typ] = e
+			}
+			for _, v := range f {
+				name, value, ok := strings.Cut(v, "=")
+				if !ok {
+					name, value = camel(v), v
+				}
+				e.names, e.values = append(e.names, name), append(e.values, value)
+			}
+		case "attrs":
+			a := &attr{name: f[0]}
+			switch len(f) {
+			case 2:
+			case 5:
+				a.fn, a.method = f[1], f[2]
+				if a.method == "-" {
+					a.method = a.fn
+				}
+				if a.param, a.typ, err = parseParam(f[3]); err != nil {
+					return nil, nil, errorf("%v", err)
+				}
+				if e := byType[a.typ]; e != nil {
+					if !slices.Contains(e.attrs, a.name) {
+						e.attrs = append(e.attrs, a.name)
+					}
+				} else if !validType(a.typ) {
+					return nil, nil, errorf("unknown type %s", a.typ)
+				}
+			default:
+				return nil, nil, errorf("want 2 or 5 fields")
+			}
+			if el := f[len(f)-1]; el != "*" {
+				a.elements = strings.Split(el, ",")
+			}
+			items = append(items, item{attr: a})
+		default:
+			return nil, nil, errorf("outside of a section")
+		}
+	}
+	return items, enums, nil
+}
+
+// parseHelpers reads a table of attribute helpers of a sub-package:
+// "name Func param:type doc", where lines starting with "+" continue the doc.
+func parseHelpers(path string) ([]item, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	var items []item
+	for _, line := range lines {
+		if line == "/**/" {
+			items = append(items, item{line: line})
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "+"); ok {
+			if len(items) == 0 || items[len(items)-1].helper == nil {
+				return nil, fmt.Errorf("%s: %q: continuation without a helper", path, line)
+			}
+			h := items[len(items)-1].helper
+			h.doc = append(h.doc, strings.TrimSpace(rest))
+			continue
+		}
+		f := strings.SplitN(line, " ", 4)
+		if len(f) != 4 {
+			return nil, fmt.Errorf("%s: %q: want name, function, parameter and doc", path, line)
+		}
+		h := &helper{name: f[0], fn: f[1], doc: []string{strings.TrimSpace(f[3])}}
+		if h.param, h.typ, err = parseParam(f[2]); err != nil || !validType(h.typ) {
+			return nil, fmt.Errorf("%s: %q: invalid parameter", path, line)
+		}
+		items = append(items, item{helper: h})
+	}
+	return items, nil
+}
+
+func parseParam(s string) (name, typ string, err error) {
+	name, typ, ok := strings.Cut(s, ":")
+	if !ok || name == "" || typ == "" {
+		return "", "", fmt.Errorf("invalid parameter %s", s)
+	}
+	return name, typ, nil
+}
+
+func validType(typ string) bool {
+	switch typ {
+	case "string", "int", "float", "value", "bool":
+		return true
+	}
+	return false
+}
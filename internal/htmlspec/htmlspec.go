@@ -0,0 +1,13 @@
+// Package htmlspec holds the tables of the HTML tree construction rules
+// shared by htm.Normalize and the parser of the sanitize package.
+package htmlspec
+
+// ClosesP lists the start tags that close an open <p>.
+var ClosesP = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "center": true, "dd": true,
+	"details": true, "dialog": true, "dir": true, "div": true, "dl": true, "dt": true, "fieldset": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hgroup": true, "hr": true, "li": true,
+	"listing": true, "main": true, "menu": true, "nav": true, "ol": true, "p": true, "plaintext": true,
+	"pre": true, "search": true, "section": true, "summary": true, "table": true, "ul": true, "xmp": true,
+}
@@ -0,0 +1,34 @@
+package live
+
+// Client is a minimal WebSocket client of a Handler, meant to drive live views in tests
+// over a loopback connection, e.g. to a server started with httptest.NewServer.
+type Client struct {
+	c *conn
+}
+
+// Dial connects to the live view at url, given with the ws or http scheme.
+func Dial(url string) (*Client, error) {
+	c, err := dial(url, DefaultMaxMessageSize<<4)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{c: c}, nil
+}
+
+// Send sends an event the way the htmx ws extension does.
+func (cl *Client) Send(ev Event) error {
+	msg, err := encodeEvent(ev)
+	if err != nil {
+		return err
+	}
+	return cl.c.writeFrame(opText, msg)
+}
+
+// Receive waits for the next message from the server: HTML in ModeOOB, JSON patches in ModeDiff.
+func (cl *Client) Receive() (string, error) {
+	msg, err := cl.c.readMessage()
+	return string(msg), err
+}
+
+// Close closes the connection.
+func (cl *Client) Close() error { return cl.c.close() }
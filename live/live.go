@@ -0,0 +1,275 @@
+// Package live keeps a component tree per browser connection over a WebSocket,
+// re-renders it on events sent from the browser and pushes back only what changed.
+//
+// Two wire formats are supported. With ModeOOB, the changed elements that have an id
+// are sent as HTML, as expected by the htmx ws extension:
+//
+//	htm.Div(hx.Ext("ws"), htm.Attr("ws-connect", "/live"), htm.Content(
+//		counter.Render(), // the element with id="counter" is replaced on every change
+//	))
+//
+// With ModeDiff, a JSON array of htm.Patch is sent, which the Script applies to the root element.
+package live
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/vapstack/htm"
+)
+
+// View is the state of one connection.
+type View interface {
+	// Render builds the tree of the view.
+	// The tree is released by the handler when it is replaced by the next one.
+	Render() *htm.Node
+	// HandleEvent updates the state of the view on an event from the browser.
+	HandleEvent(ev Event) error
+}
+
+// Event is a message sent by the browser.
+type Event struct {
+	// Trigger is the id of the element that triggered the event (HX-Trigger).
+	Trigger string
+	// TriggerName is the name of the element that triggered the event (HX-Trigger-Name).
+	TriggerName string
+	// Target is the id of the target element (HX-Target).
+	Target string
+	// Values holds the values included with the event, e.g. the fields of a submitted form.
+	Values url.Values
+}
+
+// Mode selects the format of the updates sent to the browser.
+type Mode uint8
+
+const (
+	// ModeOOB sends the changed elements with an id as HTML, for out-of-band swaps by id.
+	// The root of the view must have an id.
+	ModeOOB Mode = iota
+	// ModeDiff sends htm.Diff patches as JSON.
+	ModeDiff
+)
+
+// DefaultMaxMessageSize limits the size of messages from the browser if Handler.MaxMessageSize is zero.
+const DefaultMaxMessageSize = 1 << 20
+
+// Handler serves live view connections.
+// It renders the view when the connection opens and sends the whole root,
+// so the browser always starts from the server state; then it waits for events,
+// and after each one re-renders the view and sends the changes.
+type Handler struct {
+	// Mount creates the view of a new connection.
+	Mount func(r *http.Request) (View, error)
+	// Mode selects the format of the updates.
+	Mode Mode
+	// Renderer, if set, renders the HTML sent to the browser.
+	Renderer *htm.Renderer
+	// MaxMessageSize limits the size of messages from the browser.
+	MaxMessageSize int64
+	// ErrorLog, if set, receives the errors that end a connection, except for a normal close.
+	ErrorLog func(r *http.Request, err error)
+	// CheckOrigin reports whether the upgrade request may be accepted.
+	// If nil, requests with an Origin header whose host differs from the Host of the request are rejected,
+	// so other sites can't open connections with the cookies of the user.
+	CheckOrigin func(r *http.Request) bool
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	max := h.MaxMessageSize
+	if max <= 0 {
+		max = DefaultMaxMessageSize
+	}
+	check := h.CheckOrigin
+	if check == nil {
+		check = sameOrigin
+	}
+	if !check(r) {
+		http.Error(w, "websocket origin not allowed", http.StatusForbidden)
+		return
+	}
+	c, err := upgrade(w, r, max)
+	if err != nil {
+		return
+	}
+	defer c.close()
+
+	if err = h.serve(c, r); err != nil && !errors.Is(err, io.EOF) && h.ErrorLog != nil {
+		h.ErrorLog(r, err)
+	}
+}
+
+// sameOrigin accepts requests without an Origin header, which come from clients other than browsers,
+// and requests from the host they are sent to.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+func (h *Handler) serve(c *conn, r *http.Request) error {
+	view, err := h.Mount(r)
+	if err != nil {
+		return err
+	}
+	tree := view.Render()
+	defer func() { tree.Release() }()
+
+	if err = h.send(c, nil, tree); err != nil {
+		return err
+	}
+	for {
+		msg, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+		ev, err := DecodeEvent(msg)
+		if err != nil {
+			return err
+		}
+		if err = view.HandleEvent(ev); err != nil {
+			return err
+		}
+		next := view.Render()
+		err = h.send(c, tree, next)
+		tree.Release()
+		tree = next
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// send writes the changes from old to new; a nil old sends the whole tree.
+func (h *Handler) send(c *conn, old, new *htm.Node) error {
+	var buf bytes.Buffer
+	switch h.Mode {
+	case ModeDiff:
+		var patches []htm.Patch
+		if old == nil {
+			html, err := h.render(new)
+			if err != nil {
+				return err
+			}
+			patches = []htm.Patch{{Op: htm.PatchReplace, Path: []int{}, HTML: html}}
+		} else if patches = htm.DiffWith(h.Renderer, old, new); len(patches) == 0 {
+			return nil
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(patches); err != nil {
+			return err
+		}
+		buf.Truncate(buf.Len() - 1) // newline added by Encode
+	default:
+		fragments := []*htm.Node{new}
+		if old != nil {
+			if fragments = htm.DiffFragmentsWith(h.Renderer, old, new); len(fragments) == 0 {
+				return nil
+			}
+		}
+		for _, n := range fragments {
+			html, err := h.render(n)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(html)
+		}
+	}
+	return c.writeFrame(opText, buf.Bytes())
+}
+
+func (h *Handler) render(n *htm.Node) (string, error) {
+	var sb strings.Builder
+	var err error
+	if h.Renderer != nil {
+		err = h.Renderer.Render(&sb, n)
+	} else {
+		err = n.Render(&sb)
+	}
+	return sb.String(), err
+}
+
+// DecodeEvent decodes a message in the format of the htmx ws extension:
+// a JSON object of values with the request headers in the HEADERS field.
+func DecodeEvent(msg []byte) (Event, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &raw); err != nil {
+		return Event{}, fmt.Errorf("live: invalid event: %w", err)
+	}
+	ev := Event{Values: make(url.Values, len(raw))}
+	for name, v := range raw {
+		if name == "HEADERS" {
+			var headers map[string]string
+			if err := json.Unmarshal(v, &headers); err != nil {
+				return Event{}, fmt.Errorf("live: invalid event headers: %w", err)
+			}
+			ev.Trigger = headers["HX-Trigger"]
+			ev.TriggerName = headers["HX-Trigger-Name"]
+			ev.Target = headers["HX-Target"]
+			continue
+		}
+		var list []json.RawMessage
+		if err := json.Unmarshal(v, &list); err != nil {
+			list = []json.RawMessage{v}
+		}
+		for _, item := range list {
+			var s string
+			if err := json.Unmarshal(item, &s); err != nil {
+				s = string(item) // numbers and booleans
+			}
+			ev.Values.Add(name, s)
+		}
+	}
+	return ev, nil
+}
+
+// encodeEvent is the inverse of DecodeEvent.
+func encodeEvent(ev Event) ([]byte, error) {
+	m := make(map[string]any, len(ev.Values)+1)
+	for name, vs := range ev.Values {
+		if len(vs) == 1 {
+			m[name] = vs[0]
+		} else {
+			m[name] = vs
+		}
+	}
+	m["HEADERS"] = map[string]string{
+		"HX-Request":      "true",
+		"HX-Trigger":      ev.Trigger,
+		"HX-Trigger-Name": ev.TriggerName,
+		"HX-Target":       ev.Target,
+	}
+	return json.Marshal(m)
+}
+
+// Script is the client of ModeDiff. It defines htmLive(root, url), which connects to url,
+// applies received patches to root and sends submitted forms and clicks on elements
+// with a data-live attribute as events.
+const Script = htm.PatchScript + `function htmLive(root, url) {
+	const ws = new WebSocket(url);
+	const send = (el, values) => {
+		values.HEADERS = {"HX-Request": "true", "HX-Trigger": el.id, "HX-Trigger-Name": el.getAttribute("name") || el.getAttribute("data-live") || ""};
+		ws.send(JSON.stringify(values));
+	};
+	ws.onmessage = e => { root = htmPatch(root, JSON.parse(e.data)); };
+	document.addEventListener("submit", e => {
+		if (!root.contains(e.target)) return;
+		e.preventDefault();
+		send(e.target, Object.fromEntries(new FormData(e.target)));
+	});
+	document.addEventListener("click", e => {
+		const el = e.target.closest("[data-live]");
+		if (el && root.contains(el)) send(el, {});
+	});
+	return ws;
+}
+`
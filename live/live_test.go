@@ -0,0 +1,145 @@
+package live
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vapstack/htm"
+)
+
+type counterView struct {
+	count int
+	title string
+}
+
+func (v *counterView) Render() *htm.Node {
+	return htm.Div().ID("app").Content(
+		htm.H1().ID("title").Text(v.title),
+		htm.P().ID("count").Text(strconv.Itoa(v.count)),
+		htm.Button().ID("inc").Attr("data-live", "inc").Text("+"),
+	)
+}
+
+func (v *counterView) HandleEvent(ev Event) error {
+	switch ev.TriggerName {
+	case "inc":
+		v.count++
+	case "rename":
+		v.title = ev.Values.Get("title")
+	}
+	return nil
+}
+
+func serve(t *testing.T, mode Mode) *Client {
+	t.Helper()
+	h := &Handler{
+		Mode:     mode,
+		Mount:    func(r *http.Request) (View, error) { return &counterView{title: "Counter"}, nil },
+		ErrorLog: func(r *http.Request, err error) { t.Errorf("live: %v", err) },
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	cl, err := Dial(strings.Replace(srv.URL, "http://", "ws://", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cl.Close() })
+	return cl
+}
+
+func receive(t *testing.T, cl *Client) string {
+	t.Helper()
+	msg, err := cl.Receive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func Test_Handler_OOB(t *testing.T) {
+	cl := serve(t, ModeOOB)
+
+	want := `<div id="app"><h1 id="title">Counter</h1><p id="count">0</p><button id="inc" data-live="inc">+</button></div>`
+	if got := receive(t, cl); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	if err := cl.Send(Event{Trigger: "inc", TriggerName: "inc"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := receive(t, cl), `<p id="count">1</p>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	big := strings.Repeat("x", 70000) // uses the 8-byte frame length
+	if err := cl.Send(Event{TriggerName: "rename", Values: map[string][]string{"title": {big}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := receive(t, cl), `<h1 id="title">`+big+`</h1>`; got != want {
+		t.Fatalf("unexpected message of %d bytes", len(got))
+	}
+}
+
+func Test_Handler_Diff(t *testing.T) {
+	cl := serve(t, ModeDiff)
+
+	if got := receive(t, cl); !strings.HasPrefix(got, `[{"op":"replace","path":[],"html":"<div id=\"app\">`) {
+		t.Fatalf("unexpected initial message: %s", got)
+	}
+	if err := cl.Send(Event{TriggerName: "inc"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := receive(t, cl), `[{"op":"setText","path":[1,0],"value":"1"}]`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_DecodeEvent(t *testing.T) {
+	ev, err := DecodeEvent([]byte(`{"q":"go","tag":["a","b"],"n":5,"HEADERS":{"HX-Trigger":"search","HX-Target":"results"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Trigger != "search" || ev.Target != "results" || ev.Values.Get("q") != "go" ||
+		strings.Join(ev.Values["tag"], ",") != "a,b" || ev.Values.Get("n") != "5" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+}
+
+func Test_Handler_CheckOrigin(t *testing.T) {
+	upgrade := func(h *Handler, origin string) int {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/live", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		r.Header.Set("Sec-WebSocket-Version", "13")
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// the recorder can't be hijacked, so accepted upgrades fail later with 500
+	h := &Handler{}
+	if code := upgrade(h, "https://evil.example.org"); code != http.StatusForbidden {
+		t.Fatalf("unexpected status of a cross-origin upgrade: %d", code)
+	}
+	for _, origin := range []string{"", "https://example.com"} {
+		if code := upgrade(h, origin); code == http.StatusForbidden {
+			t.Fatalf("unexpected status of an upgrade from %q: %d", origin, code)
+		}
+	}
+
+	h.CheckOrigin = func(r *http.Request) bool { return r.Header.Get("Origin") == "https://app.example.org" }
+	if code := upgrade(h, "https://app.example.org"); code == http.StatusForbidden {
+		t.Fatalf("unexpected status of an allowed upgrade: %d", code)
+	}
+	if code := upgrade(h, "https://example.com"); code != http.StatusForbidden {
+		t.Fatalf("unexpected status of a rejected upgrade: %d", code)
+	}
+}
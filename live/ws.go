@@ -0,0 +1,275 @@
+package live
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// A minimal implementation of the WebSocket protocol (RFC 6455),
+// enough for text messages exchanged with browsers and the test Client.
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	errProtocol = errors.New("live: websocket protocol error")
+	errTooLarge = errors.New("live: websocket message too large")
+)
+
+type conn struct {
+	nc     net.Conn
+	br     *bufio.Reader
+	client bool // client frames are masked
+	max    int64
+
+	mu sync.Mutex // guards writes
+}
+
+func acceptKey(key string) string {
+	h := sha1.New()
+	_, _ = io.WriteString(h, key+acceptGUID)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func headerContains(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// upgrade performs the server side of the opening handshake.
+func upgrade(w http.ResponseWriter, r *http.Request, max int64) (*conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "websocket handshake expected", http.StatusBadRequest)
+		return nil, errProtocol
+	}
+	nc, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, err
+	}
+	_, err = fmt.Fprintf(brw, "HTTP/1.1 101 Switching Protocols\r\n"+
+		"Upgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", acceptKey(key))
+	if err == nil {
+		err = brw.Flush()
+	}
+	if err != nil {
+		_ = nc.Close()
+		return nil, err
+	}
+	return &conn{nc: nc, br: brw.Reader, max: max}, nil
+}
+
+// dial performs the client side of the opening handshake.
+func dial(rawURL string, max int64) (*conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "ws", "http":
+	default:
+		return nil, fmt.Errorf("live: unsupported scheme %q", u.Scheme)
+	}
+	nc, err := net.Dial("tcp", u.Host)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [16]byte
+	_, _ = rand.Read(nonce[:])
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{Scheme: "http", Host: u.Host, Path: u.Path, RawQuery: u.RawQuery},
+		Host:   u.Host,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     {key},
+			"Sec-WebSocket-Version": {"13"},
+		},
+	}
+	if err = req.Write(nc); err != nil {
+		_ = nc.Close()
+		return nil, err
+	}
+	br := bufio.NewReader(nc)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		_ = nc.Close()
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		_ = nc.Close()
+		return nil, fmt.Errorf("live: websocket handshake failed: %v", resp.Status)
+	}
+	return &conn{nc: nc, br: br, client: true, max: max}, nil
+}
+
+// readMessage returns the next text or binary message, answering control frames on the way.
+// It returns io.EOF when the peer closes the connection.
+func (c *conn) readMessage() ([]byte, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			if len(payload) > 2 {
+				payload = payload[:2]
+			}
+			_ = c.writeFrame(opClose, payload)
+			return nil, io.EOF
+		case opText, opBinary:
+			if started {
+				return nil, errProtocol
+			}
+			started = true
+			msg = payload
+		case opContinuation:
+			if !started {
+				return nil, errProtocol
+			}
+			if int64(len(msg)+len(payload)) > c.max {
+				return nil, errTooLarge
+			}
+			msg = append(msg, payload...)
+		default:
+			return nil, errProtocol
+		}
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+func (c *conn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var h [2]byte
+	if _, err = io.ReadFull(c.br, h[:]); err != nil {
+		return
+	}
+	fin, op = h[0]&0x80 != 0, h[0]&0x0f
+	masked := h[1]&0x80 != 0
+	if masked == c.client || h[0]&0x70 != 0 {
+		// servers must not mask frames, clients must mask them; no extensions are negotiated
+		return false, 0, nil, errProtocol
+	}
+	size := int64(h[1] & 0x7f)
+	switch size {
+	case 126:
+		var b [2]byte
+		if _, err = io.ReadFull(c.br, b[:]); err != nil {
+			return
+		}
+		size = int64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err = io.ReadFull(c.br, b[:]); err != nil {
+			return
+		}
+		size = int64(binary.BigEndian.Uint64(b[:]) & (1<<63 - 1))
+	}
+	if op >= opClose && (size > 125 || !fin) {
+		return false, 0, nil, errProtocol
+	}
+	if size > c.max {
+		return false, 0, nil, errTooLarge
+	}
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, size)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return
+}
+
+func (c *conn) writeFrame(op byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b := make([]byte, 0, 14+len(payload))
+	b = append(b, 0x80|op)
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		b = append(b, maskBit|byte(n))
+	case n <= 0xffff:
+		b = append(b, maskBit|126)
+		b = binary.BigEndian.AppendUint16(b, uint16(n))
+	default:
+		b = append(b, maskBit|127)
+		b = binary.BigEndian.AppendUint64(b, uint64(n))
+	}
+	if c.client {
+		var mask [4]byte
+		_, _ = rand.Read(mask[:])
+		b = append(b, mask[:]...)
+		start := len(b)
+		b = append(b, payload...)
+		for i := range payload {
+			b[start+i] ^= mask[i%4]
+		}
+	} else {
+		b = append(b, payload...)
+	}
+	_, err := c.nc.Write(b)
+	return err
+}
+
+func (c *conn) close() error {
+	_ = c.writeFrame(opClose, []byte{0x03, 0xe8}) // 1000, normal closure
+	return c.nc.Close()
+}
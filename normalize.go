@@ -0,0 +1,248 @@
+package htm
+
+import (
+	"slices"
+
+	"github.com/vapstack/htm/internal/htmlspec"
+)
+
+// Change is a modification made by Normalize.
+type Change struct {
+	// Path locates the element that was changed, e.g. "table > tr".
+	Path string
+	// Message describes the change.
+	Message string
+}
+
+func (c Change) String() string { return c.Path + ": " + c.Message }
+
+// Normalize applies the HTML tree construction rules that browsers apply to misnested markup,
+// so the rendered tree matches the DOM built from it, e.g. for htmx targets:
+//
+//   - rows directly inside <table> are wrapped in an implied <tbody>,
+//     and cells outside of a row in an implied <tr>;
+//   - <p> is closed before an element whose start tag closes it in browsers (e.g. <div>, <ul>, <table>),
+//     together with the elements opened inside it, unless one of them is a scope boundary
+//     such as <button>, <table> or <td>; e.g. <p><span>a<div> becomes <p><span>a</span></p><div>.
+//     The end tag of the closed <p> then opens an empty <p>, as in browsers;
+//   - <li>, <dt>, <dd>, <option>, <optgroup>, <tr>, <td>, <th> and <a> are closed
+//     by a nested element that implies their end tag.
+//
+// Content moved out of a closed element becomes a sibling that follows it.
+// Except for <p>, only the children of an element are checked: an element that implies the end tag
+// of an ancestor further up, e.g. the inner <li> in <li><div><li>, is left in place,
+// while browsers close the ancestor there. Formatting elements closed with a <p>, like <b>,
+// are not reopened around the moved content.
+// The tree is changed in place; if the root itself is split, a Group of the parts is returned.
+// Groups are flattened in elements whose content changes.
+// Content rendered by components, raw nodes and slots filled at render time is not seen.
+func Normalize(n *Node) (*Node, []Change) {
+	if n == nil {
+		return nil, nil
+	}
+	var z normalizer
+	if n.tag == "$group" {
+		content := flattenGroups(n.content, nil)
+		res := make([]*Node, 0, len(content))
+		for _, c := range content {
+			res = append(res, z.node(c)...)
+		}
+		if len(z.changes) > 0 {
+			setContent(n, res)
+		}
+		return n, z.changes
+	}
+	res := z.node(n)
+	if len(res) == 1 {
+		return res[0], z.changes
+	}
+	return Group(res...), z.changes
+}
+
+type normalizer struct {
+	stack   []*Node
+	changes []Change
+	// closing is the <p> closed by a descendant while the content after it is moved out
+	closing *Node
+}
+
+func (z *normalizer) change(n *Node, msg string) {
+	z.changes = append(z.changes, Change{Path: elementPath(z.stack, n), Message: msg})
+}
+
+// node normalizes n and returns the nodes that replace it in its parent.
+// While a <p> is being closed by a descendant, the nodes after n are moved out of its ancestors.
+func (z *normalizer) node(n *Node) []*Node {
+	if n.writeFn != nil || len(n.content) == 0 {
+		return []*Node{n}
+	}
+	z.stack = append(z.stack, n)
+	content := flattenGroups(n.content, nil)
+	changed := false
+	res := make([]*Node, 0, len(content))
+	var moved []*Node
+	for i, c := range content {
+		if c.writeFn == nil && htmlspec.ClosesP[c.tag] {
+			if p := z.pInButtonScope(); p != nil {
+				z.closing, moved = p, content[i:]
+				break
+			}
+		}
+		nodes := z.node(c)
+		if z.closing != nil {
+			res = append(res, nodes[0])
+			moved = slices.Concat(nodes[1:], content[i+1:])
+			break
+		}
+		changed = changed || len(nodes) != 1 || nodes[0] != c
+		res = append(res, nodes...)
+	}
+	z.stack = z.stack[:len(z.stack)-1]
+
+	if z.closing != nil {
+		setContent(n, slices.Clip(res))
+		if z.closing != n {
+			return append([]*Node{n}, moved...)
+		}
+		z.closing = nil
+		z.change(n, "closed <p> before <"+moved[0].tag+">")
+		res = []*Node{n}
+		for _, c := range moved {
+			res = append(res, z.node(c)...)
+		}
+		// the end tag of the closed p has no open p to close, so browsers open an empty one
+		return append(res, Build("p"))
+	}
+
+	if wrapped, ok := z.wrapTable(n, res); ok {
+		res, changed = wrapped, true
+	}
+	return z.split(n, res, changed)
+}
+
+// pInButtonScope returns the open <p> closed by a start tag that closes a <p>,
+// or nil if there is none in button scope.
+func (z *normalizer) pInButtonScope() *Node {
+	for i := len(z.stack) - 1; i >= 0; i-- {
+		switch z.stack[i].tag {
+		case "p":
+			return z.stack[i]
+		case "applet", "button", "caption", "html", "marquee", "object", "table", "td", "template", "th",
+			"foreignObject", "desc", "title", "mi", "mo", "mn", "ms", "mtext", "annotation-xml":
+			return nil
+		}
+	}
+	return nil
+}
+
+// wrapTable wraps rows in an implied <tbody> and cells in an implied <tr>.
+func (z *normalizer) wrapTable(n *Node, content []*Node) ([]*Node, bool) {
+	var wrap func(c *Node) (string, bool)
+	switch n.tag {
+	case "table":
+		wrap = func(c *Node) (string, bool) {
+			return "tbody", c.tag == "tr" || c.tag == "td" || c.tag == "th"
+		}
+	case "tbody", "thead", "tfoot":
+		wrap = func(c *Node) (string, bool) { return "tr", c.tag == "td" || c.tag == "th" }
+	default:
+		return content, false
+	}
+	if !slices.ContainsFunc(content, func(c *Node) bool { _, ok := wrap(c); return ok }) {
+		return content, false
+	}
+	res := make([]*Node, 0, len(content))
+	var run *Node
+	for _, c := range content {
+		tag, ok := wrap(c)
+		if !ok {
+			run = nil
+			res = append(res, c)
+			continue
+		}
+		if run == nil {
+			run = Build(tag)
+			z.change(n, "wrapped <"+c.tag+"> in an implied <"+tag+">")
+			res = append(res, run)
+		}
+		run.content = append(run.content, c)
+	}
+	// cells directly in a table get a row inside the implied tbody
+	for _, c := range res {
+		if c.tag == "tbody" && n.tag == "table" {
+			if wrapped, ok := z.wrapTable(c, c.content); ok {
+				c.content = wrapped
+			}
+		}
+	}
+	return res, true
+}
+
+// split sets the normalized content of n, closing n before the first child that implies its end tag,
+// and returns n followed by the nodes moved out of it. The content is kept if it has not changed.
+func (z *normalizer) split(n *Node, content []*Node, changed bool) []*Node {
+	i := slices.IndexFunc(content, func(c *Node) bool { return c.writeFn == nil && closes(n.tag, c.tag) })
+	if i < 0 {
+		if changed {
+			setContent(n, content)
+		}
+		return []*Node{n}
+	}
+	moved := content[i:]
+	z.change(n, "closed <"+n.tag+"> before <"+moved[0].tag+">")
+	setContent(n, slices.Clip(content[:i]))
+	return append([]*Node{n}, moved...)
+}
+
+// closes reports whether a child element tag implies the end tag of its parent.
+func closes(parent, tag string) bool {
+	switch parent {
+	case "li":
+		return tag == "li"
+	case "dt", "dd":
+		return tag == "dt" || tag == "dd"
+	case "option":
+		return tag == "option" || tag == "optgroup"
+	case "optgroup":
+		return tag == "optgroup"
+	case "tr":
+		return tag == "tr" || tag == "tbody" || tag == "thead" || tag == "tfoot"
+	case "td", "th":
+		return tag == "td" || tag == "th" || tag == "tr"
+	case "a":
+		return tag == "a"
+	}
+	return false
+}
+
+// flattenGroups flattens groups and drops nil nodes.
+func flattenGroups(content []*Node, res []*Node) []*Node {
+	for _, c := range content {
+		switch {
+		case c == nil:
+		case c.tag == "$group":
+			res = flattenGroups(c.content, res)
+		default:
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// setContent replaces the content of n, releasing the groups flattened into it.
+func setContent(n *Node, content []*Node) {
+	releaseGroups(n.content)
+	n.content = content
+}
+
+// releaseGroups releases the groups in content without the nodes they hold.
+func releaseGroups(content []*Node) {
+	for _, c := range content {
+		if c != nil && c.tag == "$group" {
+			releaseGroups(c.content)
+			clear(c.content)
+			c.content = c.content[:0]
+			put(c)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package htm
+
+import (
+	"strings"
+	"testing"
+)
+
+func normalized(n *Node) (string, string) {
+	res, changes := Normalize(n)
+	var s []string
+	for _, c := range changes {
+		s = append(s, c.String())
+	}
+	return res.String(), strings.Join(s, "\n")
+}
+
+func Test_Normalize_Table(t *testing.T) {
+	n := Table().Content(
+		Caption().Text("c"),
+		Tr().Content(Td().Text("1")),
+		Tr().Content(Td().Text("2")),
+		Tfoot().Content(Td().Text("3")),
+	)
+	got, changes := normalized(n)
+	want := `<table><caption>c</caption><tbody><tr><td>1</td></tr><tr><td>2</td></tr></tbody><tfoot><tr><td>3</td></tr></tfoot></table>`
+	if got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	wantChanges := "table > tfoot: wrapped <td> in an implied <tr>\ntable: wrapped <tr> in an implied <tbody>"
+	if changes != wantChanges {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", changes, wantChanges)
+	}
+
+	got, _ = normalized(Table().Content(Td().Text("1"), Td().Text("2")))
+	want = `<table><tbody><tr><td>1</td><td>2</td></tr></tbody></table>`
+	if got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Normalize_Paragraph(t *testing.T) {
+	n := Div().Content(P().Content(Text("a"), Span().Text("b"), Div().Text("c"), Text("d")))
+	got, changes := normalized(n)
+	want := `<div><p>a<span>b</span></p><div>c</div>d<p></p></div>`
+	if got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if want := "div > p: closed <p> before <div>"; changes != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", changes, want)
+	}
+
+	// the root itself is split
+	got, _ = normalized(P().Content(Ul()))
+	if want := `<p></p><ul></ul><p></p>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	// only the start tags that close a <p> in browsers split it
+	for _, tag := range []string{"option", "legend", "rt", "rp", "source", "track", "caption", "video"} {
+		got, changes = normalized(P().Content(Text("a"), Build(tag)))
+		if changes != "" {
+			t.Fatalf("unexpected changes for <%s>: %s (%s)", tag, changes, got)
+		}
+	}
+	got, _ = normalized(P().Content(Text("a"), Hr(), P().Text("b")))
+	if want := `<p>a</p><hr/><p>b</p><p></p>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	// a nested start tag closes the p and the elements opened inside it
+	n = Div().Content(P().Content(Span().Content(Text("a"), Div().Text("b"), Text("c")), Text("d")))
+	got, changes = normalized(n)
+	if want := `<div><p><span>a</span></p><div>b</div>cd<p></p></div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if want := "div > p: closed <p> before <div>"; changes != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", changes, want)
+	}
+
+	// but not across a button scope boundary
+	got, changes = normalized(P().Content(Button().Content(Div().Text("a"))))
+	if want := `<p><button><div>a</div></button></p>`; got != want || changes != "" {
+		t.Fatalf("unexpected:\n got: %s (%s)\nwant: %s", got, changes, want)
+	}
+}
+
+func Test_Normalize_Nesting(t *testing.T) {
+	n := Ul().Content(Li().Content(Text("a"), Li().Content(Text("b"), Li().Text("c")), Text("d")))
+	got, _ := normalized(n)
+	if want := `<ul><li>a</li><li>b</li><li>c</li>d</ul>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	n = Div().Content(A(Href("/1")).Content(Text("x"), A(Href("/2")).Text("y")))
+	got, _ = normalized(n)
+	if want := `<div><a href="/1">x</a><a href="/2">y</a></div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	n = Select().Content(Option().Content(Text("1"), Group(Option().Text("2"))), Option().Text("3"))
+	got, _ = normalized(n)
+	if want := `<select><option>1</option><option>2</option><option>3</option></select>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Normalize_Unchanged(t *testing.T) {
+	g := Group(Li().Text("1"), Li().Text("2"))
+	n := Ul().Content(g)
+	res, changes := Normalize(n)
+	if res != n || len(changes) != 0 || n.content[0] != g {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+}
+
+func Test_Normalize_Group(t *testing.T) {
+	g := Group(P().Content(Div()), Span())
+	res, changes := Normalize(g)
+	if res != g || len(changes) != 1 {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+	if got, want := res.String(), `<p></p><div></div><p></p><span></span>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	// groups flattened into a changed element are released
+	inner := Group(Option().Text("2"))
+	n := Select().Content(Option().Content(Text("1"), inner))
+	if _, changes := Normalize(n); len(changes) != 1 {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+	if inner.acquired.Load() {
+		t.Fatal("flattened group is not released")
+	}
+	n.Release()
+}
@@ -0,0 +1,367 @@
+package htm
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Renderer applies hooks to every element of the rendered trees,
+// so policies like test ids, tracing attributes or URL rewriting
+// can be applied without touching component code.
+//
+//	r := new(htm.Renderer).
+//		AttrFilter(func(name string, v htm.TypedValue) htm.TypedValue {
+//			if name == "src" {
+//				return htm.String(cdn + v.StringOrZero())
+//			}
+//			return v
+//		})
+//	err := r.Render(w, page)
+//
+// Hooks are called in the order of registration.
+// A Renderer must not be modified while it is in use.
+type Renderer struct {
+	// Context is the render context passed to nodes.
+	// If nil, the context carried by the writer, if any, is used.
+	Context *Context
+	// StripComments drops Comment nodes from the output.
+	StripComments bool
+	// Dialect selects the output syntax.
+	Dialect Dialect
+	// Escaping selects how text is escaped.
+	Escaping Escaping
+	// Scripts selects how script elements are handled.
+	Scripts ScriptPolicy
+	// Indent enables pretty printing: each block element starts on a new line,
+	// indented by Indent repeated for each nesting level. Inline elements and the content
+	// of pre, textarea, script and style are written as is, so the rendered text does not change,
+	// but the added whitespace text nodes shift child indexes: don't indent output patched with Diff.
+	Indent string
+	// Static is the cache of Static nodes. If nil, the global cache is used.
+	Static *StaticCache
+	// IDPrefix is the prefix of IDs generated during rendering. If empty, "id-" is used.
+	// Each call to Render uses a new ID sequence, so the output is deterministic.
+	IDPrefix string
+	// KeyAttr is the name of the attribute the node keys (see Node.Key) are rendered as,
+	// e.g. "data-key" or "id" for morphing libraries. Keys are not rendered if empty.
+	KeyAttr string
+	// StrictSlots makes Render return an error if a slot with content
+	// has neither been extracted nor placed into a SlotOutlet.
+	StrictSlots bool
+	// ClassMerge, if set, resolves class conflicts, e.g. TailwindClasses:
+	// of the conflicting classes of an element, only the one added last is rendered.
+	// Class methods such as HasClass see all added classes.
+	ClassMerge ClassResolver
+	// Validator, if set, checks elements against the HTML spec as they are rendered.
+	// It slows down rendering and is meant for development and tests.
+	Validator *Validator
+
+	before      []func(n *Node) bool
+	after       []func(n *Node)
+	attrFilters []func(name string, v TypedValue) TypedValue
+}
+
+// Dialect is the output syntax of a Renderer.
+type Dialect uint8
+
+const (
+	// DialectDefault closes void elements with "/>", e.g. <br/>.
+	DialectDefault Dialect = iota
+	// DialectHTML5 writes void elements without closing slash, e.g. <br>.
+	DialectHTML5
+	// DialectXML closes void elements with "/>" and writes boolean attributes
+	// with a value, e.g. disabled="disabled".
+	DialectXML
+)
+
+// Escaping is the escaping policy of a Renderer.
+type Escaping uint8
+
+const (
+	// EscapeDefault escapes HTML special characters only.
+	EscapeDefault Escaping = iota
+	// EscapeASCII additionally writes all non-ASCII characters as numeric character references,
+	// e.g. for transports that are not 8-bit clean. In scripts and styles, where character references
+	// are not decoded, JavaScript (\u00e9) and CSS (\0000e9) escapes are written instead;
+	// the content of other raw text elements is written as is.
+	EscapeASCII
+)
+
+// ScriptPolicy controls how a Renderer handles scripts.
+type ScriptPolicy uint8
+
+const (
+	// ScriptDefault rejects script elements with content unless they are marked with UnsafeScript.
+	ScriptDefault ScriptPolicy = iota
+	// ScriptAllow renders script elements with content.
+	ScriptAllow
+	// ScriptStrip drops script elements, event handler attributes (onclick, onload, ...),
+	// srcdoc and URL attributes with javascript: and vbscript: URLs, e.g. for email.
+	ScriptStrip
+)
+
+// BeforeElement registers a hook called before an element is written.
+// The hook may modify the node; if it returns false, the element and its content are skipped.
+func (r *Renderer) BeforeElement(fn func(n *Node) bool) *Renderer {
+	r.before = append(r.before, fn)
+	return r
+}
+
+// AfterElement registers a hook called after an element and its content are written.
+func (r *Renderer) AfterElement(fn func(n *Node)) *Renderer {
+	r.after = append(r.after, fn)
+	return r
+}
+
+// AttrFilter registers a filter applied to attribute values as they are written.
+// Returning Unset drops the attribute. Class and style attributes are not filtered.
+func (r *Renderer) AttrFilter(fn func(name string, v TypedValue) TypedValue) *Renderer {
+	r.attrFilters = append(r.attrFilters, fn)
+	return r
+}
+
+// Render renders n to w applying the options and hooks of r.
+// It is safe to call Render concurrently.
+func (r *Renderer) Render(w io.Writer, n *Node) error {
+	var ctx Context
+	if r.Context != nil {
+		ctx = *r.Context
+	} else if c := ContextOf(w); c != nil {
+		ctx = *c
+	}
+	ctx.Renderer = r
+	ctx.depth, ctx.lines, ctx.preformatted, ctx.written, ctx.open, ctx.ascii = 0, 0, 0, false, nil, nil
+	if ctx.IDs == nil {
+		prefix := r.IDPrefix
+		if prefix == "" {
+			prefix = "id-"
+		}
+		ctx.IDs = NewIDGenerator(prefix)
+	}
+	if cw, ok := w.(*contextWriter); ok {
+		w = cw.Writer
+	}
+	if r.Escaping == EscapeASCII {
+		ctx.ascii = &asciiWriter{Writer: w}
+		w = ctx.ascii
+	}
+	return n.Render(WithContext(w, &ctx))
+}
+
+// asciiWriter writes non-ASCII characters as numeric character references,
+// or with the escapes of the raw text element being written.
+type asciiWriter struct {
+	io.Writer
+	mode asciiMode
+}
+
+type asciiMode uint8
+
+const (
+	asciiHTML asciiMode = iota
+	asciiJS
+	asciiCSS
+	asciiRaw
+)
+
+// rawTextMode returns the mode for the content of the element.
+func rawTextMode(tag string) asciiMode {
+	switch tag {
+	case "script":
+		return asciiJS
+	case "style":
+		return asciiCSS
+	case "xmp", "iframe", "noembed", "noframes", "noscript", "plaintext":
+		return asciiRaw
+	}
+	return asciiHTML
+}
+
+func (w *asciiWriter) Write(p []byte) (int, error) {
+	if w.mode == asciiRaw {
+		return w.Writer.Write(p)
+	}
+	start := 0
+	for i := 0; i < len(p); {
+		if p[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(p[i:])
+		if r == utf8.RuneError && size == 1 {
+			i++
+			continue
+		}
+		if start < i {
+			if _, err := w.Writer.Write(p[start:i]); err != nil {
+				return start, err
+			}
+		}
+		var buf [16]byte
+		var b []byte
+		switch w.mode {
+		case asciiJS:
+			if r > 0xffff {
+				r1, r2 := utf16.EncodeRune(r)
+				b = appendJSEscape(buf[:0], r1)
+				b = appendJSEscape(b, r2)
+			} else {
+				b = appendJSEscape(buf[:0], r)
+			}
+		case asciiCSS:
+			b = append(buf[:0], '\\')
+			b = appendHex(b, uint32(r), 6)
+		default:
+			b = append(buf[:0], "&#"...)
+			b = strconv.AppendInt(b, int64(r), 10)
+			b = append(b, ';')
+		}
+		if _, err := w.Writer.Write(b); err != nil {
+			return i, err
+		}
+		i += size
+		start = i
+	}
+	if start < len(p) {
+		if _, err := w.Writer.Write(p[start:]); err != nil {
+			return start, err
+		}
+	}
+	return len(p), nil
+}
+
+func appendJSEscape(b []byte, r rune) []byte {
+	return appendHex(append(b, '\\', 'u'), uint32(r), 4)
+}
+
+// appendHex appends v as digits hex digits.
+func appendHex(b []byte, v uint32, digits int) []byte {
+	for i := digits - 1; i >= 0; i-- {
+		b = append(b, cssHex[v>>(4*i)&0xf])
+	}
+	return b
+}
+
+// isEventAttr reports whether name is an event handler attribute, e.g. onclick.
+func isEventAttr(name string) bool {
+	if len(name) <= 2 || (name[0]|0x20) != 'o' || (name[1]|0x20) != 'n' {
+		return false
+	}
+	return eventAttrs[name] || eventAttrs[strings.ToLower(name)]
+}
+
+// eventAttrs are the event handler attributes of HTML and SVG elements.
+var eventAttrs = map[string]bool{
+	"onabort": true, "onafterprint": true, "onanimationcancel": true, "onanimationend": true,
+	"onanimationiteration": true, "onanimationstart": true, "onauxclick": true, "onbeforecopy": true,
+	"onbeforecut": true, "onbeforeinput": true, "onbeforematch": true, "onbeforepaste": true,
+	"onbeforeprint": true, "onbeforetoggle": true, "onbeforeunload": true, "onbegin": true, "onblur": true,
+	"oncancel": true, "oncanplay": true, "oncanplaythrough": true, "onchange": true, "onclick": true,
+	"onclose": true, "oncommand": true, "oncontextlost": true, "oncontextmenu": true, "oncontextrestored": true,
+	"oncopy": true, "oncuechange": true, "oncut": true, "ondblclick": true, "ondrag": true, "ondragend": true,
+	"ondragenter": true, "ondragexit": true, "ondragleave": true, "ondragover": true, "ondragstart": true,
+	"ondrop": true, "ondurationchange": true, "onemptied": true, "onend": true, "onended": true,
+	"onerror": true, "onfocus": true, "onfocusin": true, "onfocusout": true, "onformdata": true,
+	"onfullscreenchange": true, "onfullscreenerror": true, "ongotpointercapture": true, "onhashchange": true,
+	"oninput": true, "oninvalid": true, "onkeydown": true, "onkeypress": true, "onkeyup": true,
+	"onlanguagechange": true, "onload": true, "onloadeddata": true, "onloadedmetadata": true, "onloadend": true,
+	"onloadstart": true, "onlostpointercapture": true, "onmessage": true, "onmessageerror": true,
+	"onmousedown": true, "onmouseenter": true, "onmouseleave": true, "onmousemove": true, "onmouseout": true,
+	"onmouseover": true, "onmouseup": true, "onmousewheel": true, "onoffline": true, "ononline": true,
+	"onpagehide": true, "onpagereveal": true, "onpageshow": true, "onpageswap": true, "onpaste": true,
+	"onpause": true, "onplay": true, "onplaying": true, "onpointercancel": true, "onpointerdown": true,
+	"onpointerenter": true, "onpointerleave": true, "onpointermove": true, "onpointerout": true,
+	"onpointerover": true, "onpointerrawupdate": true, "onpointerup": true, "onpopstate": true,
+	"onprogress": true, "onratechange": true, "onrejectionhandled": true, "onrepeat": true, "onreset": true,
+	"onresize": true, "onscroll": true, "onscrollend": true, "onscrollsnapchange": true,
+	"onscrollsnapchanging": true, "onsearch": true, "onsecuritypolicyviolation": true, "onseeked": true,
+	"onseeking": true, "onselect": true, "onselectionchange": true, "onselectstart": true, "onslotchange": true,
+	"onstalled": true, "onstorage": true, "onsubmit": true, "onsuspend": true, "ontimeupdate": true,
+	"ontoggle": true, "ontouchcancel": true, "ontouchend": true, "ontouchmove": true, "ontouchstart": true,
+	"ontransitioncancel": true, "ontransitionend": true, "ontransitionrun": true, "ontransitionstart": true,
+	"onunhandledrejection": true, "onunload": true, "onvolumechange": true, "onwaiting": true,
+	"onwebkitanimationend": true, "onwebkitanimationiteration": true, "onwebkitanimationstart": true,
+	"onwebkittransitionend": true, "onwheel": true, "onzoom": true,
+}
+
+// scriptURLAttrs are the attributes stripped by ScriptStrip if their value is a script URL.
+var scriptURLAttrs = map[string]bool{
+	"action": true, "background": true, "data": true, "formaction": true, "href": true,
+	"poster": true, "src": true, "xlink:href": true,
+}
+
+// isScriptURL reports whether the URL runs a script, ignoring the characters browsers ignore,
+// so that " java\tscript:" is seen as "javascript:".
+func isScriptURL(v TypedValue) bool {
+	var u string
+	switch v.Kind() {
+	case KindString:
+		u = v.StringOrZero()
+	case KindBytes:
+		u = string(v.BytesOrZero())
+	default:
+		return false
+	}
+	var b [len("javascript:")]byte
+	n := 0
+	for i := 0; i < len(u) && n < len(b); i++ {
+		c := u[i]
+		switch {
+		case c == '\t' || c == '\n' || c == '\r':
+			continue
+		case n == 0 && c <= ' ':
+			continue
+		case c >= 'A' && c <= 'Z':
+			c |= 0x20
+		}
+		b[n] = c
+		n++
+		if c == ':' {
+			break
+		}
+	}
+	s := b[:n]
+	return string(s) == "javascript:" || string(s) == "vbscript:"
+}
+
+// isBlock reports whether a new line may be started before the element without changing the rendered text.
+func isBlock(tag string) bool {
+	_, ok := htmlElements[tag]
+	return ok && !isPhrasing(tag)
+}
+
+// isPreformatted reports whether whitespace is significant in the content of the element.
+func isPreformatted(tag string) bool {
+	switch tag {
+	case "pre", "textarea", "script", "style", "listing", "xmp", "plaintext":
+		return true
+	}
+	return false
+}
+
+func (r *Renderer) beforeElement(n *Node) bool {
+	for _, fn := range r.before {
+		if !fn(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Renderer) afterElement(n *Node) {
+	for _, fn := range r.after {
+		fn(n)
+	}
+}
+
+func (r *Renderer) filterAttr(name string, v TypedValue) TypedValue {
+	for _, fn := range r.attrFilters {
+		if v = fn(name, v); !v.Valid() {
+			return v
+		}
+	}
+	return v
+}
@@ -0,0 +1,213 @@
+package htm
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_Renderer_Hooks(t *testing.T) {
+	n := Div().Content(
+		Comment("note --> <b>"),
+		Img().Attr("src", "/a.png").Attr("data-secret", "x"),
+		Span().Class("debug").Text("hidden"),
+		Button().Attr("data-testid", "keep").Text("ok"),
+	)
+	defer n.Release()
+
+	if got, want := n.String(), `<div><!-- note - -&gt; &lt;b&gt; --><img src="/a.png" data-secret="x"/>`+
+		`<span class="debug">hidden</span><button data-testid="keep">ok</button></div>`; got != want {
+		t.Fatalf("unexpected without renderer:\n got: %s\nwant: %s", got, want)
+	}
+
+	var visited []string
+	r := (&Renderer{StripComments: true}).
+		BeforeElement(func(n *Node) bool { return !n.HasClass("debug") }).
+		BeforeElement(func(n *Node) bool {
+			if n.tag == "button" && !n.HasAttr("data-testid") {
+				n.Attr("data-testid", "button")
+			}
+			return true
+		}).
+		AfterElement(func(n *Node) { visited = append(visited, n.tag) }).
+		AttrFilter(func(name string, v TypedValue) TypedValue {
+			switch {
+			case strings.HasPrefix(name, "data-secret"):
+				return Unset
+			case name == "src":
+				return String("https://cdn.example.com" + v.StringOrZero())
+			}
+			return v
+		})
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, n); err != nil {
+		t.Fatal(err)
+	}
+	want := `<div><img src="https://cdn.example.com/a.png"/><button data-testid="keep">ok</button></div>`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if got := strings.Join(visited, ","); got != "img,button,div" {
+		t.Fatalf("unexpected after hooks order: %s", got)
+	}
+}
+
+func Test_Renderer_Options(t *testing.T) {
+	script := Script().Content(RawString("alert(1)"))
+	script.UnsafeScript()
+	n := Div().Content(
+		Input().Attr("disabled").Attr("onclick", "x()"),
+		script,
+		P().Text("Grüße"),
+	)
+	defer n.Release()
+
+	render := func(r *Renderer) string {
+		var buf bytes.Buffer
+		if err := r.Render(&buf, n); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	cases := []struct {
+		r    *Renderer
+		want string
+	}{
+		{&Renderer{Dialect: DialectHTML5}, `<div><input disabled onclick="x()"><script>alert(1)</script><p>Grüße</p></div>`},
+		{&Renderer{Dialect: DialectXML}, `<div><input disabled="disabled" onclick="x()"/><script>alert(1)</script><p>Grüße</p></div>`},
+		{&Renderer{Scripts: ScriptStrip, Escaping: EscapeASCII}, `<div><input disabled/><p>Gr&#252;&#223;e</p></div>`},
+		{&Renderer{Indent: "  ", Scripts: ScriptStrip}, "<div><input disabled/>\n  <p>Grüße</p>\n</div>"},
+	}
+	for i, c := range cases {
+		if got := render(c.r); got != c.want {
+			t.Fatalf("case %d:\n got: %s\nwant: %s", i, got, c.want)
+		}
+	}
+}
+
+func Test_Renderer_IndentKeepsText(t *testing.T) {
+	r := &Renderer{Indent: "  "}
+	cases := []struct {
+		n    *Node
+		want string
+	}{
+		{P().Content(Text("a"), B().Text("b")), `<p>a<b>b</b></p>`},
+		{Div().Content(Pre().Content(Text("a\n"), Span().Text("b"), Div().Text("c")), P().Text("d")),
+			"<div>\n  <pre>a\n<span>b</span><div>c</div></pre>\n  <p>d</p>\n</div>"},
+		{Ul().Content(Li().Content(Text("a "), Em().Text("b"))), "<ul>\n  <li>a <em>b</em></li>\n</ul>"},
+	}
+	for i, c := range cases {
+		var buf bytes.Buffer
+		if err := r.Render(&buf, c.n); err != nil {
+			t.Fatal(err)
+		}
+		c.n.Release()
+		if got := buf.String(); got != c.want {
+			t.Fatalf("case %d:\n got: %s\nwant: %s", i, got, c.want)
+		}
+	}
+}
+
+func Test_Renderer_StaticCacheAndIDs(t *testing.T) {
+	fn := func() *Node { return Input().Attr("readonly") }
+
+	html := &Renderer{Dialect: DialectHTML5, Static: new(StaticCache), IDPrefix: "r-"}
+	xml := &Renderer{Dialect: DialectXML, Static: new(StaticCache)}
+
+	n := Static(fn)
+	defer n.Release()
+
+	for i := 0; i < 2; i++ {
+		var a, b bytes.Buffer
+		if err := html.Render(&a, n); err != nil {
+			t.Fatal(err)
+		}
+		if err := xml.Render(&b, n); err != nil {
+			t.Fatal(err)
+		}
+		if a.String() != `<input readonly>` || b.String() != `<input readonly="readonly"/>` {
+			t.Fatalf("unexpected static output: %s / %s", a.String(), b.String())
+		}
+	}
+
+	var id string
+	idNode := Get()
+	idNode.tag = "$id"
+	idNode.writeFn = func(n *Node, w io.Writer) error {
+		id = IDsOf(w).Next()
+		return nil
+	}
+	defer idNode.Release()
+	for i := 0; i < 2; i++ {
+		if err := html.Render(io.Discard, idNode); err != nil {
+			t.Fatal(err)
+		}
+		if id != "r-1" {
+			t.Fatalf("unexpected id: %s", id)
+		}
+	}
+}
+
+func Test_Renderer_StaticWithoutContext(t *testing.T) {
+	fn := func() *Node { return A().Attr("href", "/a").Text("a") }
+	n := Static(fn)
+	defer n.Release()
+
+	r := new(Renderer).AttrFilter(func(name string, v TypedValue) TypedValue {
+		return String("https://cdn.example.com" + v.StringOrZero())
+	})
+	var buf bytes.Buffer
+	if err := r.Render(&buf, n); err != nil {
+		t.Fatal(err)
+	}
+	want := `<a href="/a">a</a>`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Renderer_EscapeASCIIRawText(t *testing.T) {
+	script := Script().Content(RawString(`alert("é😀")`))
+	script.UnsafeScript()
+	n := Div().Attr("title", "é").Content(
+		script,
+		StyleTag().Content(RawString(`p::before{content:"é"}`)),
+		P().Text("é"),
+	)
+	defer n.Release()
+
+	var buf bytes.Buffer
+	if err := (&Renderer{Escaping: EscapeASCII}).Render(&buf, n); err != nil {
+		t.Fatal(err)
+	}
+	want := `<div title="&#233;"><script>alert("\u00e9\ud83d\ude00")</script>` +
+		`<style>p::before{content:"\0000e9"}</style><p>&#233;</p></div>`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Renderer_ScriptStripAttributes(t *testing.T) {
+	n := Div().Attr("one", "1").Attr("only-x", "2").Attr("onclick", "x()").Attr("ONLOAD", "x()").Content(
+		A().Attr("href", " java\tscript:alert(1)").Text("a"),
+		A().Attr("href", "/javascript:").Text("b"),
+		Form().Attr("action", "VBScript:x"),
+		Iframe().Attr("srcdoc", "<script>x()</script>").Attr("src", "/f"),
+	)
+	defer n.Release()
+
+	var buf bytes.Buffer
+	if err := (&Renderer{Scripts: ScriptStrip}).Render(&buf, n); err != nil {
+		t.Fatal(err)
+	}
+	want := `<div one="1" only-x="2"><a>a</a><a href="/javascript:">b</a><form></form><iframe src="/f"></iframe></div>`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,338 @@
+package sanitize
+
+import (
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/vapstack/htm"
+	"github.com/vapstack/htm/internal/htmlspec"
+)
+
+// Parse parses an HTML fragment or document into a Group of pooled nodes.
+// Elements are closed as browsers close them: a <p> before a block, an <li> before the next <li>,
+// and so on; the tree is then normalized with htm.Normalize. Comments, doctypes and processing
+// instructions are dropped, character references are decoded.
+// The content of <script>, <style> and other raw text elements is kept as raw nodes,
+// and scripts are marked with UnsafeScript so they render as parsed.
+// As in browsers, elements are nested at most 512 deep; only the first 256 attributes of an element are kept.
+//
+// Parse does not sanitize anything: the tree renders what the markup would render in a browser,
+// use a Policy for untrusted input.
+func Parse(s string) *htm.Node {
+	p := &parser{s: s, stack: []*htm.Node{htm.Group()}, open: make(map[string]int)}
+	p.run()
+	root, _ := htm.Normalize(p.stack[0])
+	return root
+}
+
+// Limits that keep the parser linear on hostile input: implied and unmatched end tags
+// search the open elements, and attributes are stored in a list.
+const (
+	maxDepth      = 512 // as in browsers
+	maxAttributes = 256 // the rest are dropped
+)
+
+type parser struct {
+	s     string
+	i     int
+	stack []*htm.Node    // open elements, the root group first
+	open  map[string]int // the number of open elements by tag
+}
+
+func (p *parser) run() {
+	for p.i < len(p.s) {
+		j := strings.IndexByte(p.s[p.i:], '<')
+		if j < 0 {
+			p.text(p.s[p.i:])
+			return
+		}
+		p.text(p.s[p.i : p.i+j])
+		p.i += j
+		if !p.markup() {
+			p.text("<")
+			p.i++
+		}
+	}
+}
+
+func (p *parser) top() *htm.Node { return p.stack[len(p.stack)-1] }
+
+func (p *parser) text(s string) {
+	if s != "" {
+		p.top().Append(htm.Text(html.UnescapeString(s)))
+	}
+}
+
+// markup consumes the tag, comment or declaration at p.i and reports whether there was one.
+func (p *parser) markup() bool {
+	rest := p.s[p.i+1:]
+	switch {
+	case strings.HasPrefix(rest, "!--"):
+		if end := strings.Index(rest[3:], "-->"); end >= 0 {
+			p.i += 1 + 3 + end + 3
+		} else {
+			p.i = len(p.s)
+		}
+	case rest != "" && (rest[0] == '!' || rest[0] == '?'):
+		p.skipPast('>')
+	case len(rest) > 1 && rest[0] == '/' && isLetter(rest[1]):
+		p.i += 2
+		name := p.name()
+		p.skipPast('>')
+		p.endTag(name)
+	case len(rest) > 1 && rest[0] == '/':
+		p.skipPast('>') // "</>" and bogus comments
+	case rest != "" && isLetter(rest[0]):
+		p.i++
+		p.startTag()
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *parser) skipPast(c byte) {
+	if end := strings.IndexByte(p.s[p.i:], c); end >= 0 {
+		p.i += end + 1
+	} else {
+		p.i = len(p.s)
+	}
+}
+
+// name consumes a tag or attribute name.
+func (p *parser) name() string {
+	start := p.i
+	for p.i < len(p.s) && !isSpace(p.s[p.i]) && p.s[p.i] != '/' && p.s[p.i] != '>' &&
+		(p.s[p.i] != '=' || p.i == start) {
+		p.i++
+	}
+	return strings.ToLower(p.s[start:p.i])
+}
+
+func (p *parser) skipSpace() {
+	for p.i < len(p.s) && isSpace(p.s[p.i]) {
+		p.i++
+	}
+}
+
+func (p *parser) startTag() {
+	n := htm.Build(p.name())
+	var seen map[string]bool
+	for {
+		p.skipSpace()
+		if p.i >= len(p.s) {
+			n.Release() // a tag cut off by the end of input is dropped
+			return
+		}
+		if c := p.s[p.i]; c == '>' {
+			p.i++
+			break
+		} else if c == '/' {
+			p.i++ // self-closing flags are ignored, as in browsers
+			continue
+		}
+		name := p.name()
+		p.skipSpace()
+		v, hasValue := "", p.i < len(p.s) && p.s[p.i] == '='
+		if hasValue {
+			p.i++
+			p.skipSpace()
+			v = p.value()
+		}
+		if seen[name] || len(seen) == maxAttributes {
+			continue // the first one wins, as in browsers
+		}
+		if seen == nil {
+			seen = make(map[string]bool)
+		}
+		seen[name] = true
+		switch {
+		case name == "class":
+			n.Class(html.UnescapeString(v))
+		case name == "style":
+			n.Style(html.UnescapeString(v))
+		case hasValue:
+			n.Attr(name, html.UnescapeString(v))
+		default:
+			n.Attr(name)
+		}
+	}
+
+	tag, void := n.GetTag()
+	p.implyEnd(tag)
+	p.top().Append(n)
+	if void {
+		return
+	}
+	if rawText[tag] || rcdata[tag] {
+		p.rawText(n, tag)
+		return
+	}
+	if len(p.stack) > maxDepth {
+		return // the content of elements nested too deep goes to their parent, as in browsers
+	}
+	p.stack = append(p.stack, n)
+	p.open[tag]++
+}
+
+// pop closes the open elements from i on.
+func (p *parser) pop(i int) {
+	for _, n := range p.stack[i:] {
+		t, _ := n.GetTag()
+		p.open[t]--
+	}
+	p.stack = p.stack[:i]
+}
+
+// value consumes an attribute value.
+func (p *parser) value() string {
+	if p.i >= len(p.s) {
+		return ""
+	}
+	if q := p.s[p.i]; q == '"' || q == '\'' {
+		p.i++
+		end := strings.IndexByte(p.s[p.i:], q)
+		if end < 0 {
+			end = len(p.s) - p.i
+		}
+		v := p.s[p.i : p.i+end]
+		p.i = min(p.i+end+1, len(p.s))
+		return v
+	}
+	start := p.i
+	for p.i < len(p.s) && !isSpace(p.s[p.i]) && p.s[p.i] != '>' {
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// rawText consumes the content of a raw text element up to its end tag.
+func (p *parser) rawText(n *htm.Node, tag string) {
+	end := p.i
+	for {
+		j := strings.Index(p.s[end:], "</")
+		if j < 0 {
+			end = len(p.s)
+			break
+		}
+		end += j
+		if k := end + 2 + len(tag); k <= len(p.s) && strings.EqualFold(p.s[end+2:k], tag) &&
+			(k == len(p.s) || isSpace(p.s[k]) || p.s[k] == '/' || p.s[k] == '>') {
+			break
+		}
+		end += 2
+	}
+	if content := p.s[p.i:end]; rcdata[tag] {
+		n.Append(htm.Text(html.UnescapeString(content)))
+	} else {
+		n.Append(htm.RawString(content))
+	}
+	if tag == "script" {
+		n.UnsafeScript()
+	}
+	p.i = end
+	p.skipPast('>')
+}
+
+// implyEnd closes the open elements whose end tag is implied by the start tag.
+func (p *parser) implyEnd(tag string) {
+	if htmlspec.ClosesP[tag] {
+		p.close("p", "button")
+	}
+	switch tag {
+	case "li":
+		p.close("li", "ol", "ul", "menu")
+	case "dt", "dd":
+		p.close("dt", "dl")
+		p.close("dd", "dl")
+	case "tr", "tbody", "thead", "tfoot":
+		p.close("td", "tr")
+		p.close("th", "tr")
+		p.close("tr", "tbody", "thead", "tfoot")
+		if tag != "tr" {
+			p.close("tbody")
+			p.close("thead")
+			p.close("tfoot")
+		}
+	case "td", "th":
+		p.close("td", "tr")
+		p.close("th", "tr")
+	case "option":
+		p.closeTop("option")
+	case "optgroup":
+		p.closeTop("option")
+		p.closeTop("optgroup")
+	case "a":
+		p.close("a")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		p.closeTop("h1", "h2", "h3", "h4", "h5", "h6")
+	}
+}
+
+// endTag closes the open element with the tag. An end tag without an open element is ignored,
+// except for </p> and </br>, which browsers turn into elements.
+func (p *parser) endTag(tag string) {
+	if p.close(tag) {
+		return
+	}
+	switch tag {
+	case "p":
+		p.top().Append(htm.P())
+	case "br":
+		p.top().Append(htm.Br())
+	}
+}
+
+// close closes the innermost open element with the tag and the elements inside it.
+// It stops at the scope boundaries and at the elements in stop.
+func (p *parser) close(tag string, stop ...string) bool {
+	if p.open[tag] == 0 {
+		return false
+	}
+	for i := len(p.stack) - 1; i > 0; i-- {
+		t, _ := p.stack[i].GetTag()
+		if t == tag {
+			p.pop(i)
+			return true
+		}
+		if scope[t] && !(tableParts[tag] && cells[t]) || slices.Contains(stop, t) {
+			return false
+		}
+	}
+	return false
+}
+
+// closeTop closes the current element if it has one of the tags.
+func (p *parser) closeTop(tags ...string) {
+	if len(p.stack) == 1 {
+		return
+	}
+	if t, _ := p.top().GetTag(); slices.Contains(tags, t) {
+		p.pop(len(p.stack) - 1)
+	}
+}
+
+var (
+	// rawText elements contain text that is not parsed as markup.
+	rawText = set("script", "style", "xmp", "iframe", "noembed", "noframes", "noscript", "plaintext")
+	// rcdata elements contain text with character references.
+	rcdata = set("textarea", "title")
+	// scope elements are boundaries for implied and unmatched end tags.
+	scope = set("applet", "caption", "html", "table", "td", "th", "marquee", "object", "template")
+	// tableParts are closed by their end tags across the cells inside them.
+	tableParts = set("table", "tbody", "thead", "tfoot", "tr")
+	cells      = set("caption", "td", "th")
+)
+
+func set(names ...string) map[string]bool {
+	m := make(map[string]bool, len(names))
+	for _, name := range names {
+		m[name] = true
+	}
+	return m
+}
+
+func isLetter(c byte) bool { return 'a' <= c|0x20 && c|0x20 <= 'z' }
+
+func isSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' }
@@ -0,0 +1,345 @@
+// Package sanitize builds safe node trees from untrusted HTML, such as user comments or Markdown output.
+//
+// A Policy lists the elements and attributes that are kept, the URL schemes allowed in links
+// and images, and the classes that may be used; everything else is removed:
+//
+//	var ugc = sanitize.UGCPolicy()
+//
+//	htm.Div().Class("comment").Content(ugc.SanitizeHTML(comment.Body))
+//
+// The result is built from pooled nodes and never shares nodes with the input.
+package sanitize
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/vapstack/htm"
+)
+
+// DefaultDropContent lists the elements removed together with their content by a Policy.
+var DefaultDropContent = []string{
+	"script", "style", "template", "iframe", "object", "embed", "noscript", "noembed", "noframes",
+	"textarea", "select", "title", "xmp", "head", "svg", "math",
+}
+
+// Policy describes what a sanitized tree may contain.
+// Elements that are not allowed are removed and their content is kept in their place,
+// except for the elements in DropContent. Inline styles, srcdoc and event handler attributes
+// are always removed, even if allowed, as are components and other nodes rendered by functions.
+//
+// A Policy must not be changed once in use; it is safe for concurrent use.
+type Policy struct {
+	// Elements maps the allowed elements to the attributes allowed on them, in addition to GlobalAttrs.
+	Elements map[string][]string
+	// GlobalAttrs lists the attributes allowed on all allowed elements.
+	GlobalAttrs []string
+	// URLSchemes lists the schemes allowed in URL attributes such as href, src and cite.
+	// Relative URLs are always allowed; attributes with other URLs are removed.
+	URLSchemes []string
+	// Classes lists the allowed class names.
+	Classes []string
+	// ClassPrefixes lists the prefixes of allowed class names, e.g. "language-" for code blocks.
+	ClassPrefixes []string
+	// DropContent lists the elements removed together with their content.
+	// If nil, DefaultDropContent is used.
+	DropContent []string
+	// NoFollow adds rel="nofollow" to links with absolute URLs.
+	NoFollow bool
+	// NoOpener adds rel="noopener noreferrer" to links with a target other than _self.
+	NoOpener bool
+	// Target, if set, replaces the target of links with absolute URLs, e.g. "_blank".
+	// Otherwise a target is kept only if Elements allows it.
+	Target string
+
+	once   sync.Once
+	attrs  map[string]map[string]bool // by element, including global attributes
+	drop   map[string]bool
+	scheme map[string]bool
+}
+
+// UGCPolicy returns a new policy for user-generated content: text formatting, headings, lists,
+// quotes, code, tables, links and images with http, https and mailto URLs,
+// and classes with the "language-" prefix. Links get rel="nofollow" and, with a target, rel="noopener noreferrer".
+func UGCPolicy() *Policy {
+	return &Policy{
+		Elements: map[string][]string{
+			"a":          {"href", "hreflang"},
+			"abbr":       nil,
+			"b":          nil,
+			"bdi":        nil,
+			"bdo":        nil,
+			"blockquote": {"cite"},
+			"br":         nil,
+			"caption":    nil,
+			"cite":       nil,
+			"code":       nil,
+			"col":        {"span"},
+			"colgroup":   {"span"},
+			"dd":         nil,
+			"del":        {"cite", "datetime"},
+			"details":    {"open"},
+			"dfn":        nil,
+			"div":        nil,
+			"dl":         nil,
+			"dt":         nil,
+			"em":         nil,
+			"figcaption": nil,
+			"figure":     nil,
+			"h1":         nil,
+			"h2":         nil,
+			"h3":         nil,
+			"h4":         nil,
+			"h5":         nil,
+			"h6":         nil,
+			"hr":         nil,
+			"i":          nil,
+			"img":        {"src", "alt", "width", "height", "loading"},
+			"ins":        {"cite", "datetime"},
+			"kbd":        nil,
+			"li":         {"value"},
+			"mark":       nil,
+			"ol":         {"start", "reversed", "type"},
+			"p":          nil,
+			"pre":        nil,
+			"q":          {"cite"},
+			"rp":         nil,
+			"rt":         nil,
+			"ruby":       nil,
+			"s":          nil,
+			"samp":       nil,
+			"small":      nil,
+			"span":       nil,
+			"strong":     nil,
+			"sub":        nil,
+			"summary":    nil,
+			"sup":        nil,
+			"table":      nil,
+			"tbody":      nil,
+			"td":         {"colspan", "rowspan", "headers"},
+			"tfoot":      nil,
+			"th":         {"colspan", "rowspan", "headers", "scope", "abbr"},
+			"thead":      nil,
+			"time":       {"datetime"},
+			"tr":         nil,
+			"u":          nil,
+			"ul":         nil,
+			"var":        nil,
+			"wbr":        nil,
+		},
+		GlobalAttrs:   []string{"title", "lang", "dir"},
+		URLSchemes:    []string{"http", "https", "mailto"},
+		ClassPrefixes: []string{"language-"},
+		NoFollow:      true,
+		NoOpener:      true,
+	}
+}
+
+func (p *Policy) init() {
+	p.attrs = make(map[string]map[string]bool, len(p.Elements))
+	for el, attrs := range p.Elements {
+		m := make(map[string]bool, len(attrs)+len(p.GlobalAttrs))
+		for _, a := range slices.Concat(p.GlobalAttrs, attrs) {
+			m[strings.ToLower(a)] = true
+		}
+		p.attrs[strings.ToLower(el)] = m
+	}
+	drop := p.DropContent
+	if drop == nil {
+		drop = DefaultDropContent
+	}
+	p.drop = make(map[string]bool, len(drop))
+	for _, el := range drop {
+		p.drop[strings.ToLower(el)] = true
+	}
+	p.scheme = make(map[string]bool, len(p.URLSchemes))
+	for _, s := range p.URLSchemes {
+		p.scheme[strings.ToLower(s)] = true
+	}
+}
+
+// SanitizeHTML parses s and returns the sanitized tree, or nil if nothing is left.
+func (p *Policy) SanitizeHTML(s string) *htm.Node {
+	n := Parse(s)
+	defer n.Release()
+	return p.Sanitize(n)
+}
+
+// Sanitize returns a sanitized copy of the tree, or nil if nothing is left.
+// Raw nodes are parsed as HTML and sanitized. If more than one node is left at the top, they are returned in a Group.
+// The tree itself is not changed.
+func (p *Policy) Sanitize(n *htm.Node) *htm.Node {
+	if n == nil {
+		return nil
+	}
+	p.once.Do(p.init)
+	g := htm.Group()
+	p.node(g, n)
+	nodes := g.ExtractContent()
+	switch len(nodes) {
+	case 0:
+		g.Release()
+		return nil
+	case 1:
+		g.Release()
+		return nodes[0]
+	}
+	return g.Append(nodes...)
+}
+
+// node appends the sanitized copy of n to dst.
+func (p *Policy) node(dst, n *htm.Node) {
+	tag, void := n.GetTag()
+	switch tag {
+	case "$text":
+		dst.Append(htm.TextValue(n.GetText()))
+		return
+	case "$raw":
+		v := n.GetText()
+		s, ok := v.String()
+		if !ok {
+			s = string(v.BytesOrZero())
+		}
+		parsed := Parse(s)
+		p.node(dst, parsed)
+		parsed.Release()
+		return
+	case "$group":
+		p.content(dst, n)
+		return
+	}
+	if strings.HasPrefix(tag, "$") || p.drop[tag] {
+		return
+	}
+	attrs, ok := p.attrs[tag]
+	if !ok {
+		p.content(dst, n)
+		return
+	}
+
+	e := htm.Build(tag).SetTagEx(tag, void)
+	n.EachAttr(func(name string, v htm.TypedValue) bool {
+		if !attrs[name] || alwaysDropped(name) {
+			return true
+		}
+		if urlAttrs[name] || name == "srcset" {
+			s, ok := v.String()
+			if !ok || !p.urls(name, s) {
+				return true
+			}
+		}
+		e.AttrValue(name, v)
+		return true
+	})
+	n.EachClass(func(c string) bool {
+		if slices.Contains(p.Classes, c) || slices.ContainsFunc(p.ClassPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(c, prefix)
+		}) {
+			e.Class(c)
+		}
+		return true
+	})
+	if tag == "a" || tag == "area" {
+		p.link(e)
+	}
+	if !void {
+		p.content(e, n)
+	}
+	dst.Append(e)
+}
+
+func (p *Policy) content(dst, n *htm.Node) {
+	n.EachContent(func(c *htm.Node) bool {
+		p.node(dst, c)
+		return true
+	})
+}
+
+// link sets the target and rel of a link.
+func (p *Policy) link(e *htm.Node) {
+	href, ok := e.GetAttr("href").String()
+	if !ok {
+		return
+	}
+	absolute := isAbsolute(href)
+	if p.Target != "" && absolute {
+		e.Attr("target", p.Target)
+	}
+	rel := strings.Fields(e.GetAttr("rel").StringOrZero())
+	n := len(rel)
+	if p.NoFollow && absolute && !slices.Contains(rel, "nofollow") {
+		rel = append(rel, "nofollow")
+	}
+	if target := e.GetAttr("target").StringOrZero(); p.NoOpener && target != "" && target != "_self" {
+		for _, r := range []string{"noopener", "noreferrer"} {
+			if !slices.Contains(rel, r) {
+				rel = append(rel, r)
+			}
+		}
+	}
+	if len(rel) > n {
+		e.Attr("rel", strings.Join(rel, " "))
+	}
+}
+
+// alwaysDropped reports whether the attribute is removed even if a Policy allows it:
+// inline styles and iframe documents can't be checked, and event handlers run scripts.
+func alwaysDropped(name string) bool {
+	name = strings.ToLower(name)
+	return name == "style" || name == "srcdoc" || strings.HasPrefix(name, "on")
+}
+
+// urlAttrs are the attributes with URL values checked against Policy.URLSchemes.
+var urlAttrs = map[string]bool{
+	"action": true, "background": true, "cite": true, "data": true, "formaction": true, "href": true,
+	"icon": true, "longdesc": true, "manifest": true, "ping": true, "poster": true, "src": true,
+	"xlink:href": true,
+}
+
+// urls reports whether the URLs in the value of an attribute are allowed.
+func (p *Policy) urls(attr, v string) bool {
+	if attr != "srcset" {
+		return p.url(v)
+	}
+	for _, candidate := range strings.Split(v, ",") {
+		if f := strings.Fields(candidate); len(f) > 0 && !p.url(f[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Policy) url(u string) bool {
+	s, ok := scheme(u)
+	return !ok || p.scheme[s]
+}
+
+// scheme returns the lowercase scheme of a URL, ignoring the whitespace that browsers ignore,
+// so that "java\tscript:" is seen as "javascript".
+func scheme(u string) (string, bool) {
+	u = strings.TrimLeft(u, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\t\n\x0b\x0c\r\x0e\x0f"+
+		"\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f ")
+	var sb strings.Builder
+	for i := 0; i < len(u); i++ {
+		switch c := u[i]; c {
+		case '\t', '\n', '\r':
+		case ':':
+			return strings.ToLower(sb.String()), true
+		case '/', '?', '#':
+			return "", false
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", false
+}
+
+// isAbsolute reports whether u has a scheme or is protocol-relative.
+func isAbsolute(u string) bool {
+	if _, ok := scheme(u); ok {
+		return true
+	}
+	u = strings.TrimSpace(u)
+	return len(u) > 1 && (u[0] == '/' || u[0] == '\\') && (u[1] == '/' || u[1] == '\\')
+}
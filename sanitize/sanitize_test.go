@@ -0,0 +1,159 @@
+package sanitize
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vapstack/htm"
+)
+
+func Test_Parse_Tree(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{`<p>a &amp; b<br>c</p>`, `<p>a &amp; b<br/>c</p>`},
+		{`<P CLASS="x" class="y" id=main hidden>t`, `<p class="x" id="main" hidden>t</p>`},
+		{`<ul><li>a<li>b</ul>`, `<ul><li>a</li><li>b</li></ul>`},
+		{`<p>a<div>b</div>c</p>`, `<p>a</p><div>b</div>c<p></p>`},
+		{`<table><tr><td>1<td>2</table>`, `<table><tbody><tr><td>1</td><td>2</td></tr></tbody></table>`},
+		{`<!-- c --><!DOCTYPE html>a < b</x>`, `a &lt; b`},
+		{`<script>if (a</b) {}</script>`, `<script>if (a</b) {}</script>`},
+		{`<title>a &lt;b&gt;</title>`, `<title>a &lt;b&gt;</title>`},
+		{`<a href="x`, ``},
+	}
+	for _, tt := range tests {
+		n := Parse(tt.in)
+		got := n.String()
+		n.Release()
+		if got != tt.want {
+			t.Fatalf("unexpected for %s:\n got: %s\nwant: %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func Test_Parse_HostileInput(t *testing.T) {
+	// quadratic parsing of this takes minutes
+	const count = 50000
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("<div>", count))
+	sb.WriteString(strings.Repeat("</span>", count))
+	sb.WriteString("<p")
+	for i := 0; i < count; i++ {
+		sb.WriteString(" a" + strconv.Itoa(i) + " b")
+	}
+	sb.WriteString(">")
+
+	start := time.Now()
+	n := Parse(sb.String())
+	defer n.Release()
+	if d := time.Since(start); d > 5*time.Second {
+		t.Fatalf("parsing took %v", d)
+	}
+
+	// elements nested too deep are added to the deepest open one, and extra attributes are dropped
+	var want strings.Builder
+	want.WriteString(strings.Repeat("<div>", 512))
+	want.WriteString(strings.Repeat("<div></div>", count-512))
+	want.WriteString("<p a0 b")
+	for i := 1; i < 255; i++ {
+		want.WriteString(" a" + strconv.Itoa(i))
+	}
+	want.WriteString("></p>")
+	want.WriteString(strings.Repeat("</div>", 512))
+	if got := n.String(); got != want.String() {
+		t.Fatalf("unexpected:\n got: %.200s\nwant: %.200s", got, want.String())
+	}
+}
+
+func Test_Sanitize_UGC(t *testing.T) {
+	ugc := UGCPolicy()
+	tests := []struct{ in, want string }{
+		{`<b onclick="x()">bold</b>`, `<b>bold</b>`},
+		{`<script>alert(1)</script><style>p{}</style>ok`, `ok`},
+		{`<form action="/x"><input name="q">text</form>`, `text`},
+		{`<p style="color:red" class="big">t</p>`, `<p>t</p>`},
+		{`<pre><code class="language-go x">c</code></pre>`, `<pre><code class="language-go">c</code></pre>`},
+		{`<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href=" java&#9;script:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href="/local">x</a>`, `<a href="/local">x</a>`},
+		{`<a href="https://example.com" target="_blank">x</a>`, `<a href="https://example.com" rel="nofollow">x</a>`},
+		{`<a href="//example.com">x</a>`, `<a href="//example.com" rel="nofollow">x</a>`},
+		{`<img src="data:image/png;base64,AAAA" alt="a">`, `<img alt="a"/>`},
+		{`<img src="https://example.com/a.png" alt="a">`, `<img src="https://example.com/a.png" alt="a"/>`},
+		{`<svg><a href="#">x</a></svg>y`, `y`},
+	}
+	for _, tt := range tests {
+		n := ugc.SanitizeHTML(tt.in)
+		got := ""
+		if n != nil {
+			got = n.String()
+			n.Release()
+		}
+		if got != tt.want {
+			t.Fatalf("unexpected for %s:\n got: %s\nwant: %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func Test_Sanitize_Links(t *testing.T) {
+	p := &Policy{
+		Elements:   map[string][]string{"a": {"href", "target", "rel"}},
+		URLSchemes: []string{"https"},
+		NoOpener:   true,
+		Target:     "_blank",
+	}
+	n := p.SanitizeHTML(`<a href="https://a.com" rel="author">a</a><a href="/b" target="_top">b</a><a href="/c">c</a>`)
+	defer n.Release()
+	got := n.String()
+	want := `<a href="https://a.com" rel="author noopener noreferrer" target="_blank">a</a>` +
+		`<a href="/b" target="_top" rel="noopener noreferrer">b</a><a href="/c">c</a>`
+	if got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Sanitize_Tree(t *testing.T) {
+	n := htm.Div().Attr("onclick", "x()").Content(
+		htm.Span().Class("a b").Text("text <b>"),
+		htm.RawString(`<em>raw</em><script>x()</script>`),
+		htm.Group(htm.Text("!"), htm.Iframe().Attr("src", "https://example.com")),
+	)
+	p := &Policy{
+		Elements: map[string][]string{"div": nil, "span": nil, "em": nil},
+		Classes:  []string{"b"},
+	}
+	defer n.Release()
+	res := p.Sanitize(n)
+	defer res.Release()
+	got := res.String()
+	want := `<div><span class="b">text &lt;b&gt;</span><em>raw</em>!</div>`
+	if got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if got, want := n.String(), `<div onclick="x()">`; got[:len(want)] != want {
+		t.Fatalf("input was changed: %s", got)
+	}
+}
+
+func Test_Sanitize_AlwaysDropped(t *testing.T) {
+	p := &Policy{
+		Elements:    map[string][]string{"div": {"style", "onclick"}, "iframe": {"srcdoc", "title"}},
+		GlobalAttrs: []string{"STYLE", "OnLoad"},
+		DropContent: []string{},
+	}
+	n := p.SanitizeHTML(`<div style="background:url(x)" onclick="x()" onload="y()">a</div>` +
+		`<iframe srcdoc="<script>x()</script>" title="t"></iframe>`)
+	defer n.Release()
+	want := `<div>a</div><iframe title="t"></iframe>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	src := htm.Div().Style("color: red").Attr("STYLE", "x").Text("b")
+	defer src.Release()
+	res := p.Sanitize(src)
+	defer res.Release()
+	if got, want := res.String(), `<div>b</div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
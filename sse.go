@@ -0,0 +1,198 @@
+package htm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSEWriter streams rendered nodes as Server-Sent Events,
+// e.g. for the htmx sse extension (see hx.SSEConnect and hx.SSESwap).
+//
+//	sse := htm.NewSSEWriter(w)
+//	for update := range updates {
+//		if err := sse.Send("stats", statsPanel(update)); err != nil {
+//			return
+//		}
+//	}
+//
+// An SSEWriter is not safe for concurrent use.
+type SSEWriter struct {
+	// Renderer, if set, is used to render the nodes.
+	Renderer *Renderer
+
+	w   io.Writer
+	rc  *http.ResponseController
+	buf bytes.Buffer // the event being rendered
+}
+
+// NewSSEWriter sets the event stream headers on w and returns a writer of events.
+func NewSSEWriter(w http.ResponseWriter) *SSEWriter {
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	return &SSEWriter{w: w, rc: http.NewResponseController(w)}
+}
+
+var (
+	sseData    = []byte("data: ")
+	sseEvent   = []byte("event: ")
+	sseID      = []byte("id: ")
+	sseRetry   = []byte("retry: ")
+	sseComment = []byte(": ")
+
+	sseEmptyData = []byte("data: \n")
+
+	errSSEField = errors.New("htm: SSE field must not contain line breaks")
+)
+
+// Send renders n as the data of an event with the given name, releases n and flushes the stream.
+// An empty event name sends a message event.
+func (s *SSEWriter) Send(event string, n *Node) error {
+	return s.SendWithID("", event, n)
+}
+
+// SendWithID is like Send, but also sets the event ID, which the browser sends back
+// in the Last-Event-ID header when it reconnects.
+// The event is rendered before anything is written, so a failed render writes nothing.
+func (s *SSEWriter) SendWithID(id, event string, n *Node) error {
+	defer n.Release()
+	if strings.ContainsAny(id, "\r\n") || strings.ContainsAny(event, "\r\n") {
+		return errSSEField
+	}
+	s.buf.Reset()
+	if id != "" {
+		_ = writeSSEField(&s.buf, sseID, id)
+	}
+	if event != "" {
+		_ = writeSSEField(&s.buf, sseEvent, event)
+	}
+	dw := &sseDataWriter{w: &s.buf, start: true}
+	var err error
+	if s.Renderer != nil {
+		err = s.Renderer.Render(dw, n)
+	} else {
+		err = n.Render(dw)
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case !dw.any:
+		// an event without data lines is ignored by browsers
+		s.buf.Write(sseEmptyData)
+	case !dw.start:
+		s.buf.Write(newline)
+	}
+	s.buf.Write(newline)
+	if _, err := s.w.Write(s.buf.Bytes()); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// Retry tells the browser how long to wait before reconnecting after the connection is lost.
+func (s *SSEWriter) Retry(d time.Duration) error {
+	if err := s.field(sseRetry, strconv.FormatInt(d.Milliseconds(), 10)); err != nil {
+		return err
+	}
+	return s.end()
+}
+
+// Comment writes a comment line, ignored by the browser.
+func (s *SSEWriter) Comment(text string) error {
+	if err := s.field(sseComment, text); err != nil {
+		return err
+	}
+	return s.end()
+}
+
+// Heartbeat writes an empty comment, which keeps the connection from being closed by proxies.
+func (s *SSEWriter) Heartbeat() error { return s.Comment("") }
+
+func (s *SSEWriter) field(name []byte, value string) error {
+	if strings.ContainsAny(value, "\r\n") {
+		return errSSEField
+	}
+	return writeSSEField(s.w, name, value)
+}
+
+func writeSSEField(w io.Writer, name []byte, value string) error {
+	if _, err := w.Write(name); err != nil {
+		return err
+	}
+	if _, err := WriteString(w, value); err != nil {
+		return err
+	}
+	_, err := w.Write(newline)
+	return err
+}
+
+// end terminates the event and flushes the stream.
+func (s *SSEWriter) end() error {
+	if _, err := s.w.Write(newline); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *SSEWriter) flush() error {
+	if s.rc == nil {
+		return nil
+	}
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// sseDataWriter writes everything as data lines, starting a new line at every line break.
+type sseDataWriter struct {
+	w     io.Writer
+	start bool // at the start of a line
+	cr    bool // the last byte was "\r"
+	any   bool // a data line was written
+}
+
+func (dw *sseDataWriter) Write(p []byte) (int, error) {
+	i := 0
+	for i < len(p) {
+		if dw.cr {
+			dw.cr = false
+			if p[i] == '\n' {
+				// "\r\n" is a single line break
+				i++
+				continue
+			}
+		}
+		if dw.start {
+			if _, err := dw.w.Write(sseData); err != nil {
+				return i, err
+			}
+			dw.start, dw.any = false, true
+		}
+		j := i
+		for j < len(p) && p[j] != '\n' && p[j] != '\r' {
+			j++
+		}
+		if j > i {
+			if _, err := dw.w.Write(p[i:j]); err != nil {
+				return i, err
+			}
+		}
+		if j == len(p) {
+			break
+		}
+		if _, err := dw.w.Write(newline); err != nil {
+			return j, err
+		}
+		dw.start, dw.cr = true, p[j] == '\r'
+		i = j + 1
+	}
+	return len(p), nil
+}
@@ -0,0 +1,46 @@
+package htm
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_SSEWriter_Framing(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sse := NewSSEWriter(rec)
+
+	if err := sse.Retry(3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := sse.SendWithID("7", "stats", Div().Content(Text("a"), RawString("\r\nb\nc\r"), RawString("\nd"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := sse.Send("", Group()); err != nil {
+		t.Fatal(err)
+	}
+	if err := sse.Heartbeat(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sse.Send("bad\nevent", Div()); err == nil {
+		t.Fatalf("expected error for event name with line break")
+	}
+	if err := sse.SendWithID("ok", "bad\nevent", Div()); err == nil {
+		t.Fatalf("expected error for event name with line break")
+	}
+	// nothing of an event that fails to render is written
+	if err := sse.SendWithID("8", "stats", Div().Content(Text("a\nb"), Marker("bad name"))); err == nil {
+		t.Fatalf("expected error for invalid marker")
+	}
+
+	want := "retry: 3000\n\n" +
+		"id: 7\nevent: stats\ndata: <div>a\ndata: b\ndata: c\ndata: d</div>\n\n" +
+		"data: \n\n" +
+		": \n\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("unexpected:\n got: %q\nwant: %q", got, want)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" || !rec.Flushed {
+		t.Fatalf("unexpected content type %q or not flushed", ct)
+	}
+}
@@ -0,0 +1,332 @@
+package htm
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+	"unsafe"
+)
+
+type cssUnit uint8
+
+const (
+	unitPx cssUnit = iota + 1
+	unitEm
+	unitRem
+	unitPercent
+	unitVw
+	unitVh
+	unitFr
+	unitDeg
+	unitMs
+)
+
+var cssUnits = [...]string{
+	unitPx:      "px",
+	unitEm:      "em",
+	unitRem:     "rem",
+	unitPercent: "%",
+	unitVw:      "vw",
+	unitVh:      "vh",
+	unitFr:      "fr",
+	unitDeg:     "deg",
+	unitMs:      "ms",
+}
+
+func (u cssUnit) String() string {
+	if int(u) < len(cssUnits) {
+		return cssUnits[u]
+	}
+	return ""
+}
+
+func length(v float64, u cssUnit) TypedValue {
+	return TypedValue{num: math.Float64bits(v), any: u}
+}
+
+// CSS lengths and other dimensions, rendered as a number followed by the unit.
+// Ems is named so to not clash with the Em tag.
+
+func Px(v float64) TypedValue      { return length(v, unitPx) }
+func Ems(v float64) TypedValue     { return length(v, unitEm) }
+func Rem(v float64) TypedValue     { return length(v, unitRem) }
+func Percent(v float64) TypedValue { return length(v, unitPercent) }
+func Vw(v float64) TypedValue      { return length(v, unitVw) }
+func Vh(v float64) TypedValue      { return length(v, unitVh) }
+func Fr(v float64) TypedValue      { return length(v, unitFr) }
+func Deg(v float64) TypedValue     { return length(v, unitDeg) }
+func Ms(v float64) TypedValue      { return length(v, unitMs) }
+
+func writeLength(w io.Writer, v TypedValue) error {
+	if err := WriteFloat(w, math.Float64frombits(v.num)); err != nil {
+		return err
+	}
+	_, err := WriteString(w, v.any.(cssUnit).String())
+	return err
+}
+
+var (
+	stylePrefix = []byte(` style="`)
+	colon       = []byte(":")
+	semicolon   = []byte(";")
+)
+
+// writeStyle writes the style attribute with the valid properties.
+// If there are none, an empty attribute is written if empty is set.
+func writeStyle(w io.Writer, props []valueEntry, empty bool) error {
+	first := true
+	for _, p := range props {
+		kind := p.value.Kind()
+		if kind == KindNone || kind == KindBool || !ValidStyleProperty(p.name) {
+			continue
+		}
+		sep := semicolon
+		if first {
+			sep = stylePrefix
+			first = false
+		}
+		if _, err := w.Write(sep); err != nil {
+			return err
+		}
+		if _, err := WriteString(w, p.name); err != nil {
+			return err
+		}
+		if _, err := w.Write(colon); err != nil {
+			return err
+		}
+		if err := writeStyleValue(w, p.value); err != nil {
+			return err
+		}
+	}
+	if first {
+		if !empty {
+			return nil
+		}
+		if _, err := w.Write(stylePrefix); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(quote)
+	return err
+}
+
+// The style attribute is stored in the style map, so attribute accessors see the properties
+// set by SetStyle and Style, and only one style attribute is ever written.
+
+func (n *Node) hasStyleAttr() bool { return n.flag&flagStyle != 0 || n.style.hasPrefix("") }
+
+// styleAttr returns the value of the style attribute as it is rendered, without HTML escaping.
+func (n *Node) styleAttr() TypedValue {
+	if !n.hasStyleAttr() {
+		return Unset
+	}
+	var sb strings.Builder
+	_ = writeStyle(&sb, n.style.o, true)
+	_, value, _ := strings.Cut(sb.String(), "=")
+	return String(html.UnescapeString(strings.Trim(value, `"`)))
+}
+
+// setStyleAttr replaces the style properties with the declarations of v.
+// Unset and false remove the attribute, true sets an empty one.
+func (n *Node) setStyleAttr(v TypedValue) {
+	n.style.reset()
+	n.flag &^= flagStyle
+	switch v.Kind() {
+	case KindNone:
+		return
+	case KindBool:
+		if !v.BoolOrZero() {
+			return
+		}
+	case KindString:
+		n.style.setDeclarations(v.StringOrZero())
+	case KindBytes:
+		n.style.setDeclarations(string(v.BytesOrZero()))
+	default:
+		n.style.setDeclarations(fmt.Sprint(v.Any()))
+	}
+	n.flag |= flagStyle
+}
+
+// moveStyleAttrTo replaces the style attribute of dst with the one of n and removes it from n.
+func (n *Node) moveStyleAttrTo(dst *Node) {
+	if n == dst || !n.hasStyleAttr() {
+		return
+	}
+	dst.style.reset()
+	n.style.movePrefixTo(dst.style, "")
+	dst.flag = dst.flag&^flagStyle | n.flag&flagStyle
+	n.setStyleAttr(Unset)
+}
+
+func writeStyleValue(w io.Writer, v TypedValue) error {
+	switch v.Kind() {
+	case KindString:
+		s := unsafe.Slice(v.any.(stringptr), v.num)
+		_, err := CSSEscapeWriter(w.Write).Write(s)
+		return err
+	case KindBytes:
+		s := unsafe.Slice(v.any.(byteptr), v.num)
+		_, err := CSSEscapeWriter(w.Write).Write(s)
+		return err
+	case KindInt64:
+		return WriteInt(w, int64(v.num))
+	case KindUint64:
+		return WriteUint(w, v.num)
+	case KindFloat64:
+		return WriteFloat(w, math.Float64frombits(v.num))
+	case KindLength:
+		return writeLength(w, v)
+	default:
+		_, err := fmt.Fprint(CSSEscapeWriter(w.Write), v.Any())
+		return err
+	}
+}
+
+// ValidStyleProperty checks if the string is a valid CSS property name, including custom properties.
+func ValidStyleProperty(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '_' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// CSSEscapeWriter escapes CSS property values written into a style attribute.
+// Characters that could end the declaration (";", "{", "}", a trailing backslash),
+// line breaks and angle brackets are written as CSS escapes,
+// the rest is HTML-escaped as with EscapeWriter.
+type CSSEscapeWriter func(p []byte) (n int, err error)
+
+var cssHex = "0123456789abcdef"
+
+func (w CSSEscapeWriter) Write(p []byte) (int, error) {
+	start := 0
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch c {
+		case ';', '{', '}', '<', '>', '\n', '\r', '\f', 0:
+		case '\\':
+			if i+1 < len(p) {
+				continue
+			}
+		default:
+			continue
+		}
+		if start < i {
+			if _, err := EscapeWriter(w).Write(p[start:i]); err != nil {
+				return start, err
+			}
+		}
+		esc := [4]byte{'\\', cssHex[c>>4], cssHex[c&0xf], ' '}
+		b := esc[:]
+		if _, err := w(*(*[]byte)(noescape(unsafe.Pointer(&b)))); err != nil {
+			return i, err
+		}
+		start = i + 1
+	}
+	if start < len(p) {
+		if _, err := EscapeWriter(w).Write(p[start:]); err != nil {
+			return start, err
+		}
+	}
+	return len(p), nil
+}
+
+// setDeclarations sets properties from a CSS declaration list ("width: 10px; color: red").
+// Comments are skipped.
+func (am *attrMap) setDeclarations(css string) {
+	css = stripCSSComments(css)
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i <= len(css); i++ {
+		if i < len(css) {
+			c := css[i]
+			switch {
+			case quote != 0:
+				if c == '\\' {
+					i++
+				} else if c == quote {
+					quote = 0
+				}
+				continue
+			case c == '"' || c == '\'':
+				quote = c
+				continue
+			case c == '(':
+				depth++
+				continue
+			case c == ')':
+				depth--
+				continue
+			case c != ';' || depth > 0:
+				continue
+			}
+		}
+		decl := css[start:min(i, len(css))]
+		start = i + 1
+		for j := 0; j < len(decl); j++ {
+			if decl[j] == ':' {
+				name, value := trimCSS(decl[:j]), trimCSS(decl[j+1:])
+				if value == "" {
+					am.set(name, Unset)
+				} else {
+					am.set(name, String(value))
+				}
+				break
+			}
+		}
+	}
+}
+
+// stripCSSComments replaces the comments outside of strings with a space.
+func stripCSSComments(css string) string {
+	if !strings.Contains(css, "/*") {
+		return css
+	}
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(css); i++ {
+		c := css[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(css) {
+				sb.WriteByte(c)
+				i++
+				c = css[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '/' && i+1 < len(css) && css[i+1] == '*':
+			end := strings.Index(css[i+2:], "*/")
+			if end < 0 {
+				return sb.String()
+			}
+			i += 2 + end + 1
+			c = ' '
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+func trimCSS(s string) string {
+	for len(s) > 0 && (s[0] == ' ' || s[0] == '\t' || s[0] == '\n' || s[0] == '\r' || s[0] == '\f') {
+		s = s[1:]
+	}
+	for len(s) > 0 && (s[len(s)-1] == ' ' || s[len(s)-1] == '\t' || s[len(s)-1] == '\n' || s[len(s)-1] == '\r' || s[len(s)-1] == '\f') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,122 @@
+package htm
+
+import (
+	"io"
+	"testing"
+)
+
+func Test_Style_SetMergeRemove(t *testing.T) {
+	n := Div(Style("color: red; background: url('a;b.png'); margin: 0")).
+		SetStyle("width", Px(120)).
+		SetStyle("color", String("blue")).
+		SetStyle("--gap", Rem(1.5)).
+		SetStyle("flex", Int(1)).
+		RemoveStyle("margin")
+	defer n.Release()
+
+	want := `<div style="color:blue;background:url(&#39;a\3b b.png&#39;);width:120px;--gap:1.5rem;flex:1"></div>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if !n.HasStyle("width") || n.HasStyle("margin") || !n.HasStylePrefix("--") {
+		t.Fatalf("unexpected HasStyle results")
+	}
+	if v, unit, ok := n.GetStyle("width").Length(); !ok || v != 120 || unit != "px" {
+		t.Fatalf("unexpected length: %v %q %v", v, unit, ok)
+	}
+}
+
+func Test_Style_EscapesValues(t *testing.T) {
+	n := Div().
+		SetStyle("color", String(`red;}</style><script>x</script>`)).
+		SetStyle("font-family", String(`"A & B", sans-serif\`)).
+		SetStyle("bad name", String("x"))
+	defer n.Release()
+
+	want := `<div style="color:red\3b \7d \3c /style\3e \3c script\3e x\3c /script\3e ;font-family:&#34;A &amp; B&#34;, sans-serif\5c "></div>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Style_MoveCopy(t *testing.T) {
+	src := Div().SetStyle("width", Px(10)).SetStyle("--a", Int(1)).SetStyle("--b", Int(2))
+	dst := Span()
+	defer src.Release()
+	defer dst.Release()
+
+	src.CopyStyleTo(dst, "width").MoveStylePrefixTo(dst, "--")
+	if got, want := src.String(), `<div style="width:10px"></div>`; got != want {
+		t.Fatalf("unexpected src:\n got: %s\nwant: %s", got, want)
+	}
+	if got, want := dst.String(), `<span style="width:10px;--a:1;--b:2"></span>`; got != want {
+		t.Fatalf("unexpected dst:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Style_NoAllocations(t *testing.T) {
+	n := Div().SetStyle("width", Px(120)).SetStyle("color", String("red;")).SetStyle("z-index", Int(3))
+	defer n.Release()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := n.Render(io.Discard); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func Test_Style_Attribute(t *testing.T) {
+	n := Div().Attr("style", "color: red; margin: 0").SetStyle("width", Px(10))
+	defer n.Release()
+
+	if got, want := n.String(), `<div style="color:red;margin:0;width:10px"></div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if got := n.GetAttr("style").StringOrZero(); got != "color:red;margin:0;width:10px" {
+		t.Fatalf("unexpected GetAttr: %q", got)
+	}
+	if !n.HasAttr("style") || !n.HasAttrAll("style") || !n.HasAttrPrefix("sty") {
+		t.Fatalf("expected the style attribute")
+	}
+	var names []string
+	n.EachAttr(func(name string, _ TypedValue) bool { names = append(names, name); return true })
+	if len(names) != 1 || names[0] != "style" {
+		t.Fatalf("unexpected EachAttr: %v", names)
+	}
+
+	n.Attr("style", "top: 0")
+	if got, want := n.String(), `<div style="top:0"></div>`; got != want {
+		t.Fatalf("unexpected after replace:\n got: %s\nwant: %s", got, want)
+	}
+
+	dst := Span().Style("left: 1px")
+	defer dst.Release()
+	n.MoveAttrTo(dst, "style")
+	if n.HasAttr("style") || n.String() != `<div></div>` {
+		t.Fatalf("unexpected src after move: %s", n.String())
+	}
+	if got, want := dst.String(), `<span style="top:0"></span>`; got != want {
+		t.Fatalf("unexpected dst:\n got: %s\nwant: %s", got, want)
+	}
+
+	empty := Div(Style(""))
+	defer empty.Release()
+	if got, want := empty.String(), `<div style=""></div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	if empty.RemoveAttr("style").String() != `<div></div>` {
+		t.Fatalf("expected RemoveAttr to remove the style")
+	}
+}
+
+func Test_Style_DeclarationComments(t *testing.T) {
+	n := Div().Style("/* a: b; */ color: red; margin: 0 /* ; x: y */; content: '/* no */'")
+	defer n.Release()
+
+	if got, want := n.String(), `<div style="color:red;margin:0;content:&#39;/* no */&#39;"></div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
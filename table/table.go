@@ -0,0 +1,328 @@
+// Package table renders data tables with sortable headers, filtering and pagination
+// over a slice of typed rows. Controls are links that also carry hx-get attributes,
+// so the table works with and without htmx.
+//
+//	var users = &table.Table[User]{
+//		ID:  "users",
+//		URL: "/users",
+//		Columns: []table.Column[User]{
+//			{Key: "name", Title: "Name", Cell: func(u User) *htm.Node { return htm.Text(u.Name) },
+//				Compare: func(a, b User) int { return strings.Compare(a.Name, b.Name) }},
+//			{Key: "email", Title: "Email", Cell: func(u User) *htm.Node { return htm.Text(u.Email) }},
+//		},
+//		RowKey: func(u User) any { return u.ID },
+//	}
+//
+//	s := table.StateFromQuery(r.URL.Query())
+//	page := users.Apply(all, &s)
+//	users.Render(page, s, htm.Slot(table.EmptySlot, htm.P().Text("No users yet"))).Render(w)
+//
+// Texts are translatable with htm.T.
+package table
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vapstack/htm"
+	"github.com/vapstack/htm/aria"
+	"github.com/vapstack/htm/hx"
+)
+
+// EmptySlot is the slot rendered in place of the rows when there are none.
+const EmptySlot = "empty"
+
+// Query parameters of the table state.
+const (
+	ParamSort   = "sort" // column key, prefixed with "-" for descending order
+	ParamPage   = "page"
+	ParamSize   = "size"
+	ParamFilter = "q"
+)
+
+// DefaultPageSize is used when the state has no page size.
+const DefaultPageSize = 20
+
+// State is the sorting, filtering and pagination state of a table, usually decoded from the request query.
+type State struct {
+	Sort   string // key of the sorted column
+	Desc   bool   // descending order
+	Page   int    // current page, starting at 1
+	Size   int    // rows per page
+	Filter string // filter text
+	Total  int    // total number of rows after filtering, used for pagination
+}
+
+// StateFromQuery decodes the state from query parameters, see ParamSort and others.
+func StateFromQuery(q url.Values) State {
+	s := State{Filter: q.Get(ParamFilter)}
+	s.Sort, s.Desc = strings.CutPrefix(q.Get(ParamSort), "-")
+	s.Page, _ = strconv.Atoi(q.Get(ParamPage))
+	s.Size, _ = strconv.Atoi(q.Get(ParamSize))
+	return s.normalize()
+}
+
+func (s State) normalize() State {
+	if s.Size <= 0 {
+		s.Size = DefaultPageSize
+	}
+	if s.Page < 1 {
+		s.Page = 1
+	}
+	if s.Sort == "" {
+		s.Desc = false
+	}
+	return s
+}
+
+// Pages returns the number of pages, at least 1.
+func (s State) Pages() int {
+	s = s.normalize()
+	return max(1, (s.Total+s.Size-1)/s.Size)
+}
+
+// Query encodes the state as query parameters; default values are omitted.
+func (s State) Query() url.Values {
+	q := url.Values{}
+	if s.Sort != "" {
+		if s.Desc {
+			q.Set(ParamSort, "-"+s.Sort)
+		} else {
+			q.Set(ParamSort, s.Sort)
+		}
+	}
+	if s.Page > 1 {
+		q.Set(ParamPage, strconv.Itoa(s.Page))
+	}
+	if s.Size > 0 && s.Size != DefaultPageSize {
+		q.Set(ParamSize, strconv.Itoa(s.Size))
+	}
+	if s.Filter != "" {
+		q.Set(ParamFilter, s.Filter)
+	}
+	return q
+}
+
+// Column describes a column of a table of rows of type R.
+type Column[R any] struct {
+	// Key identifies the column in the sort parameter.
+	Key string
+	// Title is the text of the header cell, translatable with htm.T.
+	Title string
+	// Cell builds the content of the cell of a row.
+	Cell func(R) *htm.Node
+	// Compare makes the column sortable; it is used by Table.Apply to sort rows in memory.
+	// Set Sortable instead if rows are sorted elsewhere, e.g. by a database query.
+	Compare func(a, b R) int
+	// Sortable makes the column sortable without Compare.
+	Sortable bool
+	// Header and Cells are applied to the header cell and to every cell of the column.
+	Header, Cells htm.Mod
+}
+
+func (c *Column[R]) sortable() bool { return c.Sortable || c.Compare != nil }
+
+// Table renders rows of type R. A Table must not be modified after first use.
+type Table[R any] struct {
+	// ID is the id of the container, the target of htmx requests of the controls.
+	ID string
+	// URL is the address the controls link to, with the state in the query.
+	URL string
+	// Columns of the table.
+	Columns []Column[R]
+	// RowKey, if set, keys the rows (see htm.Node.Key).
+	RowKey func(R) any
+	// Filter, if set, is used by Apply to keep the rows that match the filter text.
+	Filter func(row R, filter string) bool
+
+	// Mods applied to the parts of the table, to restyle it.
+	Container, Table, Head, HeaderRow, Body, Empty, Pager, PageLink, CurrentPage htm.Mod
+	// Row, if set, returns the Mod applied to the row.
+	Row func(R) htm.Mod
+}
+
+// Apply filters and sorts rows according to s and returns the current page.
+// It sets s.Total and clamps s.Page to the available pages.
+// The input slice is not modified.
+func (t *Table[R]) Apply(rows []R, s *State) []R {
+	*s = s.normalize()
+	if t.Filter != nil && s.Filter != "" {
+		filtered := make([]R, 0, len(rows))
+		for _, r := range rows {
+			if t.Filter(r, s.Filter) {
+				filtered = append(filtered, r)
+			}
+		}
+		rows = filtered
+	}
+	if c := t.column(s.Sort); c != nil && c.Compare != nil {
+		rows = slices.Clone(rows)
+		slices.SortStableFunc(rows, func(a, b R) int {
+			if s.Desc {
+				return c.Compare(b, a)
+			}
+			return c.Compare(a, b)
+		})
+	}
+	s.Total = len(rows)
+	s.Page = min(s.Page, s.Pages())
+	start := min((s.Page-1)*s.Size, len(rows))
+	return rows[start:min(start+s.Size, len(rows))]
+}
+
+func (t *Table[R]) column(key string) *Column[R] {
+	if key == "" {
+		return nil
+	}
+	for i := range t.Columns {
+		if c := &t.Columns[i]; c.Key == key && c.sortable() {
+			return c
+		}
+	}
+	return nil
+}
+
+// Render renders the rows of the current page and the controls for the state s.
+// Mods are applied to the container, e.g. to fill the EmptySlot.
+func (t *Table[R]) Render(rows []R, s State, mods ...htm.Mod) *htm.Node {
+	s = s.normalize()
+	if s.Total == 0 {
+		s.Total = len(rows)
+	}
+
+	head := htm.Tr(t.HeaderRow)
+	for i := range t.Columns {
+		head.Append(t.header(&t.Columns[i], s))
+	}
+
+	body := htm.Tbody(t.Body)
+	if len(rows) == 0 {
+		body.Append(htm.Tr().Content(
+			htm.Td(htm.Attr("colspan", strconv.Itoa(max(1, len(t.Columns)))), t.Empty).Content(
+				htm.SlotOutlet(EmptySlot, htm.T("No results")),
+			),
+		))
+	}
+	body.Append(htm.Each(rows, t.RowKey, t.row))
+
+	return htm.Div(htm.ModIf(t.ID != "", func() htm.Mod { return htm.ID(t.ID) }), t.Container).Content(
+		htm.Table(t.Table).Content(htm.Thead(t.Head).Content(head), body),
+		t.pager(s),
+	).Apply(mods)
+}
+
+func (t *Table[R]) row(r R) *htm.Node {
+	tr := htm.Tr()
+	if t.Row != nil {
+		tr.Mod(t.Row(r))
+	}
+	for i := range t.Columns {
+		c := &t.Columns[i]
+		tr.Append(htm.Td(c.Cells).Content(c.Cell(r)))
+	}
+	return tr
+}
+
+func (t *Table[R]) header(c *Column[R], s State) *htm.Node {
+	th := htm.Th(htm.Attr("scope", "col"), c.Header)
+	if !c.sortable() {
+		return th.Content(htm.T(c.Title))
+	}
+	next := s
+	next.Page = 1
+	switch {
+	case s.Sort != c.Key:
+		next.Sort, next.Desc = c.Key, false
+		th.Mod(aria.Sort("none"))
+	case s.Desc:
+		next.Desc = false
+		th.Mod(aria.Sort("descending"))
+	default:
+		next.Desc = true
+		th.Mod(aria.Sort("ascending"))
+	}
+	return th.Content(t.link(next).Content(htm.T(c.Title)))
+}
+
+func (t *Table[R]) pager(s State) *htm.Node {
+	pages := s.Pages()
+	if pages <= 1 {
+		return nil
+	}
+	nav := htm.Nav(aria.Label("Pagination"), t.Pager)
+	nav.Append(t.pageLink(s, s.Page-1, "prev", htm.T("Previous")))
+	last := 0
+	for _, p := range pageWindow(s.Page, pages) {
+		if p > last+1 {
+			nav.Append(htm.Span(htm.Attr("aria-hidden", "true")).Text("…"))
+		}
+		nav.Append(t.pageLink(s, p, "", htm.Text(strconv.Itoa(p))))
+		last = p
+	}
+	return nav.Append(t.pageLink(s, s.Page+1, "next", htm.T("Next")))
+}
+
+// pageLink returns a link to page p, or a disabled placeholder if p is the current page or out of range.
+func (t *Table[R]) pageLink(s State, p int, rel string, text *htm.Node) *htm.Node {
+	if p == s.Page {
+		return htm.Span(aria.Current("page"), t.CurrentPage).Content(text)
+	}
+	if p < 1 || p > s.Pages() {
+		return htm.Span(htm.Attr("aria-disabled", "true"), t.PageLink).Content(text)
+	}
+	next := s
+	next.Page = p
+	return t.link(next).Mod(t.PageLink, htm.ModIf(rel != "", func() htm.Mod { return htm.Attr("rel", rel) })).Content(text)
+}
+
+// link returns a link to the table in state s, swapping the container with htmx.
+func (t *Table[R]) link(s State) *htm.Node {
+	href := t.href(s)
+	a := htm.A().Href(href)
+	if t.ID != "" {
+		a.Mod(hx.Get(href), hx.Target("#"+t.ID), hx.Select("#"+t.ID), hx.Swap("outerHTML"), hx.PushURL("true"))
+	}
+	return a
+}
+
+func (t *Table[R]) href(s State) string {
+	u, err := url.Parse(t.URL)
+	if err != nil {
+		u = &url.URL{Path: t.URL}
+	}
+	q := u.Query()
+	for _, p := range []string{ParamSort, ParamPage, ParamSize, ParamFilter} {
+		q.Del(p)
+	}
+	for k, v := range s.Query() {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// FilterInput returns a search input that filters the table as the user types.
+// Mods are applied to the input.
+func (t *Table[R]) FilterInput(s State, mods ...htm.Mod) *htm.Node {
+	in := htm.Input().InputType(htm.InputTypeSearch).Name(ParamFilter).Attr("value", s.Filter).Mod(aria.Label("Filter"))
+	if t.ID != "" {
+		next := s
+		next.Page, next.Filter = 1, ""
+		in.Mod(hx.Get(t.href(next)), hx.Trigger("input changed delay:300ms, search"),
+			hx.Target("#"+t.ID), hx.Select("#"+t.ID), hx.Swap("outerHTML"), hx.PushURL("true"))
+	}
+	return in.Apply(mods)
+}
+
+// pageWindow returns the pages shown by the pager: the first, the last and those around the current one.
+func pageWindow(page, pages int) []int {
+	res := make([]int, 0, 7)
+	for p := 1; p <= pages; p++ {
+		if p == 1 || p == pages || p >= page-1 && p <= page+1 {
+			res = append(res, p)
+		}
+	}
+	return res
+}
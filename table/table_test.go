@@ -0,0 +1,69 @@
+package table
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vapstack/htm"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+var users = &Table[user]{
+	ID:  "users",
+	URL: "/users?tab=all",
+	Columns: []Column[user]{
+		{Key: "id", Title: "ID", Cell: func(u user) *htm.Node { return htm.Text(strconv.Itoa(u.ID)) }},
+		{Key: "name", Title: "Name", Cell: func(u user) *htm.Node { return htm.Text(u.Name) },
+			Compare: func(a, b user) int { return strings.Compare(a.Name, b.Name) }},
+	},
+	RowKey: func(u user) any { return u.ID },
+	Filter: func(u user, q string) bool { return strings.Contains(u.Name, q) },
+	Table:  htm.Class("tbl"),
+	Row:    func(u user) htm.Mod { return htm.ModIf(u.ID == 2, func() htm.Mod { return htm.Class("hl") }) },
+}
+
+func Test_Table_SortFilterPaginate(t *testing.T) {
+	all := []user{{1, "dave"}, {2, "alice"}, {3, "carol"}, {4, "bob"}, {5, "ann"}}
+
+	s := StateFromQuery(url.Values{"sort": {"-name"}, "size": {"2"}, "page": {"2"}, "q": {"a"}})
+	rows := users.Apply(all, &s)
+	if s.Total != 4 || s.Pages() != 2 || len(rows) != 2 || rows[0].Name != "ann" || rows[1].Name != "alice" {
+		t.Fatalf("unexpected rows %v of state %+v", rows, s)
+	}
+
+	n := users.Render(rows, s)
+	defer n.Release()
+	link := func(q string) string {
+		return `href="/users?` + q + `" hx-get="/users?` + q + `" hx-target="#users" hx-select="#users" hx-swap="outerHTML" hx-push-url="true"`
+	}
+	want := `<div id="users"><table class="tbl"><thead><tr><th scope="col">ID</th>` +
+		`<th scope="col" aria-sort="descending"><a ` + link("q=a&amp;size=2&amp;sort=name&amp;tab=all") + `>Name</a></th></tr></thead>` +
+		`<tbody><tr><td>5</td><td>ann</td></tr><tr class="hl"><td>2</td><td>alice</td></tr></tbody></table>` +
+		`<nav aria-label="Pagination"><a ` + link("q=a&amp;size=2&amp;sort=-name&amp;tab=all") + ` rel="prev">Previous</a>` +
+		`<a ` + link("q=a&amp;size=2&amp;sort=-name&amp;tab=all") + `>1</a><span aria-current="page">2</span>` +
+		`<span aria-disabled="true">Next</span></nav></div>`
+	if got := n.String(); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Table_EmptySlot(t *testing.T) {
+	s := State{}
+	n := users.Render(nil, s)
+	defer n.Release()
+	if got := n.String(); !strings.Contains(got, `<tbody><tr><td colspan="2">No results</td></tr></tbody>`) || strings.Contains(got, "<nav") {
+		t.Fatalf("unexpected: %s", got)
+	}
+
+	m := users.Render(nil, s, htm.Slot(EmptySlot, htm.P().Text("No users yet")))
+	defer m.Release()
+	if got := m.String(); !strings.Contains(got, `<td colspan="2"><p>No users yet</p></td>`) {
+		t.Fatalf("unexpected: %s", got)
+	}
+}
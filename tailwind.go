@@ -0,0 +1,475 @@
+package htm
+
+import "strings"
+
+// TailwindClasses is a ClassResolver for Tailwind CSS utility classes.
+// It understands variants ("hover:", "md:", "[&>*]:"), the important modifier,
+// negative utilities, arbitrary values ("p-[3px]") and arbitrary properties ("[mask-type:alpha]").
+// Unknown classes are never treated as conflicting.
+//
+//	r := &htm.Renderer{ClassMerge: htm.TailwindClasses}
+//	r.Render(w, htm.Div().Class("px-4 py-2").Class("px-2")) // class="py-2 px-2"
+var TailwindClasses ClassResolver = tailwindResolver{}
+
+type tailwindResolver struct{}
+
+func (tailwindResolver) ClassGroup(class string) (variant, group string) {
+	depth := 0
+	base := 0
+	for i := 0; i < len(class); i++ {
+		switch class[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				base = i + 1
+			}
+		}
+	}
+	if base < len(class) && class[base] == '!' {
+		base++
+	}
+	variant, u := class[:base], class[base:]
+	u = strings.TrimSuffix(u, "!")
+	u = strings.TrimPrefix(u, "-")
+	if u == "" {
+		return "", ""
+	}
+
+	// arbitrary property
+	if u[0] == '[' {
+		if i := strings.IndexByte(u, ':'); i > 1 && u[len(u)-1] == ']' {
+			return variant, u[:i]
+		}
+		return "", ""
+	}
+
+	if g, ok := twStatic[u]; ok {
+		return variant, g
+	}
+
+	// try prefixes from longest to shortest, the value never contains the prefix
+	end := strings.IndexByte(u, '[')
+	if end < 0 {
+		end = len(u)
+	}
+	for i := end; i > 0; i-- {
+		if i < len(u) && u[i] != '-' {
+			continue
+		}
+		rules, ok := twPrefixes[u[:i]]
+		if !ok {
+			continue
+		}
+		v := ""
+		if i < len(u) {
+			v = u[i+1:]
+		}
+		for _, r := range rules {
+			if r.match == nil || r.match(v) {
+				return variant, r.group
+			}
+		}
+	}
+	return "", ""
+}
+
+func (tailwindResolver) ConflictingGroups(group string) []string {
+	return twConflicts[group]
+}
+
+type twRule struct {
+	group string
+	match func(v string) bool // nil matches any value
+}
+
+func twAny(group string) []twRule { return []twRule{{group, nil}} }
+
+func twOneOf(values ...string) func(string) bool {
+	return func(v string) bool {
+		for _, s := range values {
+			if v == s {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// twValue strips the opacity or line-height modifier ("red-500/50", "lg/7").
+func twValue(v string) string {
+	if strings.HasPrefix(v, "[") {
+		return v
+	}
+	if i := strings.IndexByte(v, '/'); i >= 0 {
+		return v[:i]
+	}
+	return v
+}
+
+func twArbitrary(v string) (string, bool) {
+	if len(v) > 1 && v[0] == '[' && v[len(v)-1] == ']' {
+		return v[1 : len(v)-1], true
+	}
+	return "", false
+}
+
+func twNumber(v string) bool {
+	if a, ok := twArbitrary(v); ok {
+		v = strings.TrimPrefix(a, "number:")
+	}
+	if v == "" {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if (v[i] < '0' || v[i] > '9') && v[i] != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func twLength(v string) bool {
+	a, ok := twArbitrary(v)
+	if !ok {
+		return twNumber(v) || v == "px"
+	}
+	if strings.HasPrefix(a, "length:") {
+		return true
+	}
+	for _, p := range []string{"calc(", "min(", "max(", "clamp("} {
+		if strings.HasPrefix(a, p) {
+			return true
+		}
+	}
+	return a != "" && (a[0] >= '0' && a[0] <= '9' || a[0] == '.')
+}
+
+func twArbitraryColor(v string) bool {
+	a, ok := twArbitrary(v)
+	if !ok {
+		return false
+	}
+	for _, p := range []string{"#", "rgb", "hsl", "oklch", "oklab", "lab(", "lch(", "color:", "color-mix("} {
+		if strings.HasPrefix(a, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// twWidth matches border-like widths: the bare utility, numbers and lengths.
+func twWidth(v string) bool { return v == "" || twLength(v) }
+
+var (
+	twFontSizeName = twOneOf("xs", "sm", "base", "lg", "xl")
+	twFontSize     = func(v string) bool {
+		v = twValue(v)
+		if twFontSizeName(v) {
+			return true
+		}
+		if strings.HasSuffix(v, "xl") && twNumber(v[:len(v)-2]) {
+			return true
+		}
+		return twLength(v)
+	}
+	twFontWeightName = twOneOf("thin", "extralight", "light", "normal", "medium", "semibold", "bold", "extrabold", "black")
+	twFontWeight     = func(v string) bool { return twFontWeightName(v) || twNumber(v) }
+	twShadowSizeName = twOneOf("", "2xs", "xs", "sm", "md", "lg", "xl", "2xl", "inner", "none")
+	twShadowSize     = func(v string) bool {
+		if twShadowSizeName(v) {
+			return true
+		}
+		_, ok := twArbitrary(v)
+		return ok && !twArbitraryColor(v)
+	}
+	twBorderStyle  = twOneOf("solid", "dashed", "dotted", "double", "hidden", "none")
+	twTextAlign    = twOneOf("left", "center", "right", "justify", "start", "end")
+	twAlignContent = twOneOf("normal", "center", "start", "end", "between", "around", "evenly", "baseline", "stretch")
+)
+
+// twBorder returns rules for a border utility and its side suffix ("", "-x", "-t", ...).
+func twBorder(side string) []twRule {
+	return []twRule{
+		{"border-w" + side, twWidth},
+		{"border-color" + side, nil},
+	}
+}
+
+var twStatic = map[string]string{
+	"block": "display", "inline-block": "display", "inline": "display", "flex": "display",
+	"inline-flex": "display", "table": "display", "inline-table": "display", "table-caption": "display",
+	"table-cell": "display", "table-column": "display", "table-column-group": "display",
+	"table-footer-group": "display", "table-header-group": "display", "table-row-group": "display",
+	"table-row": "display", "flow-root": "display", "grid": "display", "inline-grid": "display",
+	"contents": "display", "list-item": "display", "hidden": "display",
+
+	"static": "position", "fixed": "position", "absolute": "position", "relative": "position", "sticky": "position",
+
+	"visible": "visibility", "invisible": "visibility", "collapse": "visibility",
+
+	"isolate": "isolation", "isolation-auto": "isolation",
+
+	"uppercase": "text-transform", "lowercase": "text-transform", "capitalize": "text-transform", "normal-case": "text-transform",
+
+	"italic": "font-style", "not-italic": "font-style",
+
+	"antialiased": "font-smoothing", "subpixel-antialiased": "font-smoothing",
+
+	"underline": "text-decoration", "overline": "text-decoration", "line-through": "text-decoration", "no-underline": "text-decoration",
+
+	"truncate": "text-overflow", "text-ellipsis": "text-overflow", "text-clip": "text-overflow",
+
+	"sr-only": "sr", "not-sr-only": "sr",
+
+	"border-collapse": "border-collapse", "border-separate": "border-collapse",
+}
+
+var twPrefixes = map[string][]twRule{
+	// layout
+	"aspect":           twAny("aspect"),
+	"columns":          twAny("columns"),
+	"box":              {{"box-sizing", twOneOf("border", "content")}},
+	"box-decoration":   twAny("box-decoration"),
+	"float":            twAny("float"),
+	"clear":            twAny("clear"),
+	"object":           {{"object-fit", twOneOf("contain", "cover", "fill", "none", "scale-down")}, {"object-position", nil}},
+	"overflow":         twAny("overflow"),
+	"overflow-x":       twAny("overflow-x"),
+	"overflow-y":       twAny("overflow-y"),
+	"overscroll":       twAny("overscroll"),
+	"overscroll-x":     twAny("overscroll-x"),
+	"overscroll-y":     twAny("overscroll-y"),
+	"inset":            twAny("inset"),
+	"inset-x":          twAny("inset-x"),
+	"inset-y":          twAny("inset-y"),
+	"top":              twAny("top"),
+	"right":            twAny("right"),
+	"bottom":           twAny("bottom"),
+	"left":             twAny("left"),
+	"start":            twAny("start"),
+	"end":              twAny("end"),
+	"z":                twAny("z"),
+	"break-before":     twAny("break-before"),
+	"break-after":      twAny("break-after"),
+	"break-inside":     twAny("break-inside"),
+	"break":            {{"word-break", twOneOf("normal", "words", "all", "keep")}},
+	"table":            {{"table-layout", twOneOf("auto", "fixed")}},
+	"caption":          twAny("caption"),
+	"border-spacing":   twAny("border-spacing"),
+	"border-spacing-x": twAny("border-spacing-x"),
+	"border-spacing-y": twAny("border-spacing-y"),
+
+	// flexbox & grid
+	"basis":         twAny("basis"),
+	"flex":          {{"flex-direction", twOneOf("row", "row-reverse", "col", "col-reverse")}, {"flex-wrap", twOneOf("wrap", "wrap-reverse", "nowrap")}, {"flex", nil}},
+	"grow":          twAny("grow"),
+	"shrink":        twAny("shrink"),
+	"order":         twAny("order"),
+	"grid-cols":     twAny("grid-cols"),
+	"grid-rows":     twAny("grid-rows"),
+	"grid-flow":     twAny("grid-flow"),
+	"col":           twAny("col-start-end"),
+	"col-span":      twAny("col-start-end"),
+	"col-start":     twAny("col-start"),
+	"col-end":       twAny("col-end"),
+	"row":           twAny("row-start-end"),
+	"row-span":      twAny("row-start-end"),
+	"row-start":     twAny("row-start"),
+	"row-end":       twAny("row-end"),
+	"auto-cols":     twAny("auto-cols"),
+	"auto-rows":     twAny("auto-rows"),
+	"gap":           twAny("gap"),
+	"gap-x":         twAny("gap-x"),
+	"gap-y":         twAny("gap-y"),
+	"justify":       {{"justify-content", twAlignContent}},
+	"justify-items": twAny("justify-items"),
+	"justify-self":  twAny("justify-self"),
+	"content":       {{"align-content", twAlignContent}, {"content", nil}},
+	"items":         twAny("align-items"),
+	"self":          twAny("align-self"),
+	"place-content": twAny("place-content"),
+	"place-items":   twAny("place-items"),
+	"place-self":    twAny("place-self"),
+
+	// spacing
+	"p":       twAny("p"),
+	"px":      twAny("px"),
+	"py":      twAny("py"),
+	"pt":      twAny("pt"),
+	"pr":      twAny("pr"),
+	"pb":      twAny("pb"),
+	"pl":      twAny("pl"),
+	"ps":      twAny("ps"),
+	"pe":      twAny("pe"),
+	"m":       twAny("m"),
+	"mx":      twAny("mx"),
+	"my":      twAny("my"),
+	"mt":      twAny("mt"),
+	"mr":      twAny("mr"),
+	"mb":      twAny("mb"),
+	"ml":      twAny("ml"),
+	"ms":      twAny("ms"),
+	"me":      twAny("me"),
+	"space-x": twAny("space-x"),
+	"space-y": twAny("space-y"),
+
+	// sizing
+	"w":     twAny("w"),
+	"min-w": twAny("min-w"),
+	"max-w": twAny("max-w"),
+	"h":     twAny("h"),
+	"min-h": twAny("min-h"),
+	"max-h": twAny("max-h"),
+	"size":  twAny("size"),
+
+	// typography
+	"font":             {{"font-weight", twFontWeight}, {"font-family", nil}},
+	"text":             {{"text-align", twTextAlign}, {"font-size", twFontSize}, {"text-color", nil}},
+	"leading":          twAny("leading"),
+	"tracking":         twAny("tracking"),
+	"line-clamp":       twAny("line-clamp"),
+	"list":             {{"list-position", twOneOf("inside", "outside")}, {"list-style-type", nil}},
+	"decoration":       {{"decoration-style", twOneOf("solid", "double", "dotted", "dashed", "wavy")}, {"decoration-thickness", func(v string) bool { return v == "auto" || v == "from-font" || twLength(v) }}, {"decoration-color", nil}},
+	"underline-offset": twAny("underline-offset"),
+	"indent":           twAny("indent"),
+	"align":            twAny("vertical-align"),
+	"whitespace":       twAny("whitespace"),
+	"hyphens":          twAny("hyphens"),
+	"placeholder":      twAny("placeholder-color"),
+
+	// backgrounds
+	"bg": {
+		{"bg-attachment", twOneOf("fixed", "local", "scroll")},
+		{"bg-position", twOneOf("bottom", "center", "left", "left-bottom", "left-top", "right", "right-bottom", "right-top", "top")},
+		{"bg-repeat", twOneOf("repeat", "no-repeat", "repeat-x", "repeat-y", "repeat-round", "repeat-space")},
+		{"bg-size", twOneOf("auto", "cover", "contain")},
+		{"bg-image", func(v string) bool {
+			return v == "none" || strings.HasPrefix(v, "gradient-") || strings.HasPrefix(v, "linear-") || strings.HasPrefix(v, "radial") || strings.HasPrefix(v, "conic") || strings.HasPrefix(v, "[url(")
+		}},
+		{"bg-color", nil},
+	},
+	"bg-clip":   twAny("bg-clip"),
+	"bg-origin": twAny("bg-origin"),
+	"bg-blend":  twAny("bg-blend"),
+	"from":      twAny("gradient-from"),
+	"via":       twAny("gradient-via"),
+	"to":        twAny("gradient-to"),
+
+	// borders
+	"border":         append([]twRule{{"border-style", twBorderStyle}}, twBorder("")...),
+	"border-x":       twBorder("-x"),
+	"border-y":       twBorder("-y"),
+	"border-t":       twBorder("-t"),
+	"border-r":       twBorder("-r"),
+	"border-b":       twBorder("-b"),
+	"border-l":       twBorder("-l"),
+	"border-s":       twBorder("-s"),
+	"border-e":       twBorder("-e"),
+	"rounded":        twAny("rounded"),
+	"rounded-t":      twAny("rounded-t"),
+	"rounded-r":      twAny("rounded-r"),
+	"rounded-b":      twAny("rounded-b"),
+	"rounded-l":      twAny("rounded-l"),
+	"rounded-s":      twAny("rounded-s"),
+	"rounded-e":      twAny("rounded-e"),
+	"rounded-tl":     twAny("rounded-tl"),
+	"rounded-tr":     twAny("rounded-tr"),
+	"rounded-br":     twAny("rounded-br"),
+	"rounded-bl":     twAny("rounded-bl"),
+	"rounded-ss":     twAny("rounded-ss"),
+	"rounded-se":     twAny("rounded-se"),
+	"rounded-es":     twAny("rounded-es"),
+	"rounded-ee":     twAny("rounded-ee"),
+	"divide-x":       twAny("divide-x"),
+	"divide-y":       twAny("divide-y"),
+	"divide":         {{"divide-style", twBorderStyle}, {"divide-color", nil}},
+	"outline":        {{"outline-style", func(v string) bool { return v == "" || twBorderStyle(v) }}, {"outline-w", twLength}, {"outline-color", nil}},
+	"outline-offset": twAny("outline-offset"),
+	"ring":           {{"ring-w", twWidth}, {"ring-inset", twOneOf("inset")}, {"ring-color", nil}},
+	"ring-offset":    {{"ring-offset-w", twLength}, {"ring-offset-color", nil}},
+
+	// effects & filters
+	"shadow":      {{"shadow", twShadowSize}, {"shadow-color", nil}},
+	"opacity":     twAny("opacity"),
+	"mix-blend":   twAny("mix-blend"),
+	"blur":        twAny("blur"),
+	"brightness":  twAny("brightness"),
+	"contrast":    twAny("contrast"),
+	"drop-shadow": twAny("drop-shadow"),
+	"grayscale":   twAny("grayscale"),
+	"hue-rotate":  twAny("hue-rotate"),
+	"invert":      twAny("invert"),
+	"saturate":    twAny("saturate"),
+	"sepia":       twAny("sepia"),
+
+	// transitions & transforms
+	"transition":  twAny("transition"),
+	"duration":    twAny("duration"),
+	"ease":        twAny("ease"),
+	"delay":       twAny("delay"),
+	"animate":     twAny("animate"),
+	"scale":       twAny("scale"),
+	"scale-x":     twAny("scale-x"),
+	"scale-y":     twAny("scale-y"),
+	"rotate":      twAny("rotate"),
+	"translate-x": twAny("translate-x"),
+	"translate-y": twAny("translate-y"),
+	"skew-x":      twAny("skew-x"),
+	"skew-y":      twAny("skew-y"),
+	"origin":      twAny("origin"),
+
+	// interactivity & svg
+	"accent":         twAny("accent"),
+	"appearance":     twAny("appearance"),
+	"cursor":         twAny("cursor"),
+	"caret":          twAny("caret"),
+	"pointer-events": twAny("pointer-events"),
+	"resize":         twAny("resize"),
+	"scroll":         {{"scroll-behavior", twOneOf("auto", "smooth")}},
+	"select":         twAny("select"),
+	"touch":          twAny("touch"),
+	"will-change":    twAny("will-change"),
+	"fill":           twAny("fill"),
+	"stroke":         {{"stroke-w", twNumber}, {"stroke", nil}},
+}
+
+var twConflicts = map[string][]string{
+	"overflow":   {"overflow-x", "overflow-y"},
+	"overscroll": {"overscroll-x", "overscroll-y"},
+	"inset":      {"inset-x", "inset-y", "top", "right", "bottom", "left", "start", "end"},
+	"inset-x":    {"right", "left"},
+	"inset-y":    {"top", "bottom"},
+	"gap":        {"gap-x", "gap-y"},
+
+	"p":  {"px", "py", "pt", "pr", "pb", "pl", "ps", "pe"},
+	"px": {"pr", "pl"},
+	"py": {"pt", "pb"},
+	"m":  {"mx", "my", "mt", "mr", "mb", "ml", "ms", "me"},
+	"mx": {"mr", "ml"},
+	"my": {"mt", "mb"},
+
+	"size":      {"w", "h"},
+	"font-size": {"leading"},
+
+	"border-spacing": {"border-spacing-x", "border-spacing-y"},
+
+	"border-w":       {"border-w-x", "border-w-y", "border-w-t", "border-w-r", "border-w-b", "border-w-l", "border-w-s", "border-w-e"},
+	"border-w-x":     {"border-w-r", "border-w-l"},
+	"border-w-y":     {"border-w-t", "border-w-b"},
+	"border-color":   {"border-color-x", "border-color-y", "border-color-t", "border-color-r", "border-color-b", "border-color-l", "border-color-s", "border-color-e"},
+	"border-color-x": {"border-color-r", "border-color-l"},
+	"border-color-y": {"border-color-t", "border-color-b"},
+
+	"rounded":   {"rounded-t", "rounded-r", "rounded-b", "rounded-l", "rounded-s", "rounded-e", "rounded-tl", "rounded-tr", "rounded-br", "rounded-bl", "rounded-ss", "rounded-se", "rounded-es", "rounded-ee"},
+	"rounded-t": {"rounded-tl", "rounded-tr"},
+	"rounded-r": {"rounded-tr", "rounded-br"},
+	"rounded-b": {"rounded-br", "rounded-bl"},
+	"rounded-l": {"rounded-tl", "rounded-bl"},
+	"rounded-s": {"rounded-ss", "rounded-es"},
+	"rounded-e": {"rounded-se", "rounded-ee"},
+
+	"scale": {"scale-x", "scale-y"},
+}
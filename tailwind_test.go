@@ -0,0 +1,59 @@
+package htm
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Tailwind_ClassMerge(t *testing.T) {
+	r := &Renderer{ClassMerge: TailwindClasses}
+	cases := []struct {
+		classes []string
+		want    string
+	}{
+		{[]string{"px-4 py-2", "px-2"}, "py-2 px-2"},
+		{[]string{"px-4 py-2", "p-3"}, "p-3"},
+		{[]string{"p-3", "px-2"}, "p-3 px-2"},
+		{[]string{"block", "hidden md:flex", "flex"}, "md:flex flex"},
+		{[]string{"hover:bg-red-500 bg-white", "hover:bg-blue-500/50"}, "bg-white hover:bg-blue-500/50"},
+		{[]string{"text-sm text-gray-700 text-left", "text-lg text-[#333]"}, "text-left text-lg text-[#333]"},
+		{[]string{"leading-6 text-sm", "text-base/7"}, "text-base/7"},
+		{[]string{"mt-4", "-mt-2"}, "-mt-2"},
+		{[]string{"w-[200px] h-4", "size-8"}, "size-8"},
+		{[]string{"border border-gray-200", "border-2 border-dashed"}, "border-gray-200 border-2 border-dashed"},
+		{[]string{"font-bold font-sans", "font-[550]"}, "font-sans font-[550]"},
+		{[]string{"shadow-lg shadow-black", "shadow-[0_1px_2px_rgba(0,0,0,0.1)]"}, "shadow-black shadow-[0_1px_2px_rgba(0,0,0,0.1)]"},
+		{[]string{"[&>*]:p-2 p-1", "[&>*]:p-4"}, "p-1 [&>*]:p-4"},
+		{[]string{"!px-4 px-2", "!px-1"}, "px-2 !px-1"},
+		{[]string{"[mask-type:luminance]", "[mask-type:alpha]"}, "[mask-type:alpha]"},
+		{[]string{"btn btn-primary", "btn-secondary"}, "btn btn-primary btn-secondary"},
+		{[]string{"px-4 px-2", "px-4"}, "px-4"},
+	}
+	for _, c := range cases {
+		n := Div()
+		for _, cls := range c.classes {
+			n.Class(cls)
+		}
+		want := `<div class="` + c.want + `"></div>`
+		var sb strings.Builder
+		if err := r.Render(&sb, n); err != nil {
+			t.Fatal(err)
+		}
+		if got := sb.String(); got != want {
+			t.Fatalf("%v:\n got: %s\nwant: %s", c.classes, got, want)
+		}
+		n.Release()
+	}
+}
+
+func Test_Tailwind_ClassMergeDisabledByDefault(t *testing.T) {
+	n := Div().Class("px-4").Class("px-2")
+	defer n.Release()
+	if got, want := n.String(), `<div class="px-4 px-2"></div>`; got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+	// the classes are merged as they are rendered, so they are all kept in the node
+	if !n.HasClass("px-4") {
+		t.Fatalf("expected px-4 to be kept")
+	}
+}
@@ -0,0 +1,200 @@
+package htm
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Issue is a problem found by a Validator.
+type Issue struct {
+	// Path locates the element, e.g. "body > div#main > p > div".
+	Path string
+	// Message describes the problem.
+	Message string
+}
+
+func (i Issue) String() string { return i.Path + ": " + i.Message }
+
+// DefaultAttrPrefixes are the prefixes of framework attributes accepted by a Validator
+// on any element: htmx, its extensions, Alpine.js and its shorthands.
+var DefaultAttrPrefixes = []string{"hx-", "sse-", "ws-", "x-", "@", ":"}
+
+// Validator checks elements as they are rendered against the HTML spec:
+// attributes not allowed on the element and content not permitted in its parent,
+// such as a block inside <p>, <a> inside <a> or <li> outside of a list.
+// Elements that are not standard HTML, like custom elements and SVG, are not checked.
+//
+// It is meant for development and tests, set it on a Renderer:
+//
+//	r := &htm.Renderer{Validator: &htm.Validator{Report: func(i htm.Issue) { log.Println(i) }}}
+//
+// A Validator is safe for concurrent use.
+type Validator struct {
+	// AttrPrefixes lists prefixes of attributes allowed on any element.
+	// If nil, DefaultAttrPrefixes is used.
+	AttrPrefixes []string
+	// Report, if set, is called for each issue; otherwise issues are collected (see Issues).
+	Report func(Issue)
+
+	mu     sync.Mutex
+	issues []Issue
+}
+
+// Validate renders n with a Validator and returns the issues found.
+func Validate(n *Node) []Issue {
+	v := new(Validator)
+	_ = (&Renderer{Validator: v}).Render(io.Discard, n)
+	return v.Issues()
+}
+
+// Issues returns the issues collected so far.
+func (v *Validator) Issues() []Issue {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.issues)
+}
+
+// Reset drops the collected issues.
+func (v *Validator) Reset() {
+	v.mu.Lock()
+	v.issues = nil
+	v.mu.Unlock()
+}
+
+func (v *Validator) report(stack []*Node, n *Node, msg string) {
+	i := Issue{Path: elementPath(stack, n), Message: msg}
+	if v.Report != nil {
+		v.Report(i)
+		return
+	}
+	v.mu.Lock()
+	v.issues = append(v.issues, i)
+	v.mu.Unlock()
+}
+
+// check validates n rendered inside the open elements in stack.
+func (v *Validator) check(stack []*Node, n *Node) {
+	if _, ok := htmlElements[n.tag]; !ok {
+		return
+	}
+	prefixes := v.AttrPrefixes
+	if prefixes == nil {
+		prefixes = DefaultAttrPrefixes
+	}
+	n.EachAttr(func(name string, _ TypedValue) bool {
+		if !AttrAllowed(n.tag, name) && !hasAnyPrefix(name, prefixes) {
+			v.report(stack, n, "attribute "+name+" is not allowed on <"+n.tag+">")
+		}
+		return true
+	})
+
+	var parent *Node
+	if len(stack) > 0 {
+		parent = stack[len(stack)-1]
+		if _, ok := htmlElements[parent.tag]; !ok {
+			parent = nil // e.g. a custom element or <svg>, which may have any content
+		}
+	}
+	if parents, ok := requiredParents[n.tag]; ok && parent != nil && !slices.Contains(parents, parent.tag) {
+		v.report(stack, n, "<"+n.tag+"> must be a child of <"+strings.Join(parents, ">, <")+">, not <"+parent.tag+">")
+	}
+	if !isPhrasing(n.tag) && slices.ContainsFunc(stack, func(a *Node) bool { return a.tag == "p" }) {
+		v.report(stack, n, "<"+n.tag+"> is not allowed inside <p>")
+	}
+	if isInteractive(n) {
+		for _, a := range stack {
+			if a.tag == "a" || a.tag == "button" {
+				v.report(stack, n, "<"+n.tag+"> is not allowed inside <"+a.tag+">")
+				break
+			}
+		}
+	}
+	if n.tag == "form" && slices.ContainsFunc(stack, func(a *Node) bool { return a.tag == "form" }) {
+		v.report(stack, n, "<form> is not allowed inside <form>")
+	}
+}
+
+// requiredParents lists elements that are only allowed as children of some elements.
+// Templates are accepted as parents of any of them.
+var requiredParents = map[string][]string{
+	"li":         {"ol", "ul", "menu", "template"},
+	"dt":         {"dl", "div", "template"},
+	"dd":         {"dl", "div", "template"},
+	"tr":         {"table", "thead", "tbody", "tfoot", "template"},
+	"td":         {"tr", "template"},
+	"th":         {"tr", "template"},
+	"thead":      {"table", "template"},
+	"tbody":      {"table", "template"},
+	"tfoot":      {"table", "template"},
+	"caption":    {"table", "template"},
+	"colgroup":   {"table", "template"},
+	"col":        {"colgroup", "template"},
+	"option":     {"select", "datalist", "optgroup", "template"},
+	"optgroup":   {"select", "template"},
+	"legend":     {"fieldset", "template"},
+	"summary":    {"details", "template"},
+	"figcaption": {"figure", "template"},
+	"source":     {"audio", "video", "picture", "template"},
+	"track":      {"audio", "video", "template"},
+	"rt":         {"ruby", "template"},
+	"rp":         {"ruby", "template"},
+	"head":       {"html"},
+	"body":       {"html"},
+}
+
+// isPhrasing reports whether tag is phrasing content, allowed inside <p>.
+func isPhrasing(tag string) bool {
+	switch tag {
+	case "a", "abbr", "area", "audio", "b", "bdi", "bdo", "br", "button", "canvas", "cite", "code",
+		"data", "datalist", "del", "dfn", "em", "embed", "i", "iframe", "img", "input", "ins", "kbd",
+		"label", "link", "map", "mark", "meta", "meter", "noscript", "object", "output", "picture",
+		"progress", "q", "ruby", "s", "samp", "script", "select", "slot", "small", "span", "strong",
+		"sub", "sup", "template", "textarea", "time", "u", "var", "video", "wbr":
+		return true
+	}
+	return false
+}
+
+// isInteractive reports whether n is interactive content, not allowed inside <a> and <button>.
+// Some elements are interactive only with certain attributes: <audio> and <video> with controls,
+// <img> with usemap, and <input> unless it is hidden.
+func isInteractive(n *Node) bool {
+	switch n.tag {
+	case "a", "button", "details", "embed", "iframe", "label", "select", "textarea":
+		return true
+	case "audio", "video":
+		return n.HasAttr("controls")
+	case "img":
+		return n.HasAttr("usemap")
+	case "input":
+		return !strings.EqualFold(n.GetAttr("type").StringOrZero(), "hidden")
+	}
+	return false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// elementPath returns the path of n inside stack, with ids where available.
+func elementPath(stack []*Node, n *Node) string {
+	var sb strings.Builder
+	for _, e := range append(stack[:len(stack):len(stack)], n) {
+		if sb.Len() > 0 {
+			sb.WriteString(" > ")
+		}
+		sb.WriteString(e.tag)
+		if id := e.GetAttr("id"); id.Kind() == KindString {
+			sb.WriteString("#")
+			sb.WriteString(id.StringOrZero())
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,86 @@
+package htm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func issueStrings(issues []Issue) string {
+	var s []string
+	for _, i := range issues {
+		s = append(s, i.String())
+	}
+	return strings.Join(s, "\n")
+}
+
+func Test_Validate_Attributes(t *testing.T) {
+	n := Table().Content(Tr().Content(Td(Href("/x")).Text("a"), Td(ColSpan(2), Class("c"), Style("color: red"))))
+	n.Append(Tr().Content(Td(Attr("hx-get", "/y"), Attr("x-show", "open"), Attr("@click", "go()"), Data("id", Int(1)))))
+	want := "table > tr > td: attribute href is not allowed on <td>"
+	if got := issueStrings(Validate(n)); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+
+	n = Input(ColSpan(2), Attr("custom-attr"))
+	v := &Validator{AttrPrefixes: []string{"custom-"}}
+	_ = (&Renderer{Validator: v}).Render(new(bytes.Buffer), n)
+	want = "input: attribute colspan is not allowed on <input>"
+	if got := issueStrings(v.Issues()); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Validate_Content(t *testing.T) {
+	n := Div(ID("main")).Content(
+		P().Content(Span().Content(Div())),
+		A(Href("/")).Content(A(Href("/b"))),
+		Li(),
+		Ul().Content(Li(), Group(Li())),
+		Button().Content(Input()),
+		Build("my-widget").Content(Li()),
+	)
+	want := strings.Join([]string{
+		"div#main > p > span > div: <div> is not allowed inside <p>",
+		"div#main > a > a: <a> is not allowed inside <a>",
+		"div#main > li: <li> must be a child of <ol>, <ul>, <menu>, <template>, not <div>",
+		"div#main > button > input: <input> is not allowed inside <button>",
+	}, "\n")
+	if got := issueStrings(Validate(n)); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_Validate_Report(t *testing.T) {
+	var got []Issue
+	r := &Renderer{Validator: &Validator{Report: func(i Issue) { got = append(got, i) }}}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, Form().Content(Form())); err != nil {
+		t.Fatal(err)
+	}
+	want := "form > form: <form> is not allowed inside <form>"
+	if s := issueStrings(got); s != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", s, want)
+	}
+	if buf.String() != "<form><form></form></form>" {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func Test_Validate_InteractiveAttributes(t *testing.T) {
+	n := A(Href("/")).Content(
+		Input(Type("hidden"), Name("id")),
+		Img(Src("/a.png")),
+		Img(Src("/a.png"), UseMap("#m")),
+		Video(Src("/a.mp4")),
+	)
+	n.Append(Button().Content(Audio(Src("/a.mp3"), Controls()), Input(Type("HIDDEN"))))
+	want := strings.Join([]string{
+		"a > img: <img> is not allowed inside <a>",
+		"a > button: <button> is not allowed inside <a>",
+		"a > button > audio: <audio> is not allowed inside <a>",
+	}, "\n")
+	if got := issueStrings(Validate(n)); got != want {
+		t.Fatalf("unexpected:\n got: %s\nwant: %s", got, want)
+	}
+}
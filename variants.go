@@ -0,0 +1,104 @@
+package htm
+
+import (
+	"sort"
+	"sync"
+)
+
+// Variants describes the classes of a component in terms of named variant groups.
+//
+//	var btn = &htm.Variants{
+//		Base: "btn inline-flex",
+//		Variants: map[string]map[string]string{
+//			"size":   {"sm": "px-2 text-sm", "md": "px-4"},
+//			"intent": {"primary": "bg-blue-600", "danger": "bg-red-600"},
+//		},
+//		Defaults: map[string]string{"size": "md", "intent": "primary"},
+//		Compound: []htm.CompoundVariant{
+//			{When: map[string]string{"size": "sm", "intent": "danger"}, Class: "font-bold"},
+//		},
+//	}
+//
+//	htm.Button(btn.With("size", "sm", "intent", "danger"))
+//
+// The selected values are stored as node variables, so they can also be set with Var
+// before With is applied. Applying With again replaces the classes of the previous selection.
+// Variants must not be modified after first use.
+type Variants struct {
+	// Base classes are always added.
+	Base string
+	// Variants maps a group name to its values and their classes.
+	Variants map[string]map[string]string
+	// Defaults maps a group name to the value used when none is selected.
+	Defaults map[string]string
+	// Compound classes are added when all conditions of a compound variant are met.
+	Compound []CompoundVariant
+
+	once  sync.Once
+	names []string
+}
+
+// CompoundVariant adds classes to a combination of variant values.
+type CompoundVariant struct {
+	When  map[string]string
+	Class string
+}
+
+// With returns a Mod that selects variant values given as name/value pairs
+// and updates the classes of the node accordingly.
+// Unknown groups are ignored; an incomplete trailing pair is ignored as well.
+func (v *Variants) With(pairs ...string) Mod {
+	return func(n *Node) { v.apply(n, pairs) }
+}
+
+// Selected returns the value of the variant group for the node, falling back to the default.
+func (v *Variants) Selected(n *Node, name string) string {
+	if s := n.GetVar(name).StringOrZero(); s != "" {
+		return s
+	}
+	return v.Defaults[name]
+}
+
+func (v *Variants) apply(n *Node, pairs []string) {
+	v.once.Do(func() {
+		v.names = make([]string, 0, len(v.Variants))
+		for name := range v.Variants {
+			v.names = append(v.names, name)
+		}
+		sort.Strings(v.names)
+	})
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if _, ok := v.Variants[pairs[i]]; ok {
+			n.Var(pairs[i], pairs[i+1])
+		}
+	}
+
+	for _, values := range v.Variants {
+		for _, class := range values {
+			n.class.setMulti(class, false)
+		}
+	}
+	for _, c := range v.Compound {
+		n.class.setMulti(c.Class, false)
+	}
+
+	n.class.setMulti(v.Base, true)
+	for _, name := range v.names {
+		n.class.setMulti(v.Variants[name][v.Selected(n, name)], true)
+	}
+	for _, c := range v.Compound {
+		if v.matches(n, c.When) {
+			n.class.setMulti(c.Class, true)
+		}
+	}
+}
+
+func (v *Variants) matches(n *Node, when map[string]string) bool {
+	for name, value := range when {
+		if v.Selected(n, name) != value {
+			return false
+		}
+	}
+	return true
+}
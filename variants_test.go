@@ -0,0 +1,38 @@
+package htm
+
+import "testing"
+
+func Test_Variants_With(t *testing.T) {
+	btn := &Variants{
+		Base: "btn",
+		Variants: map[string]map[string]string{
+			"size":   {"sm": "btn-sm text-sm", "md": "btn-md"},
+			"intent": {"primary": "btn-primary", "danger": "btn-danger"},
+		},
+		Defaults: map[string]string{"size": "md", "intent": "primary"},
+		Compound: []CompoundVariant{
+			{When: map[string]string{"size": "sm", "intent": "danger"}, Class: "font-bold"},
+		},
+	}
+
+	cases := []struct {
+		n    *Node
+		want string
+	}{
+		{Button(btn.With()), `<button class="btn btn-primary btn-md"></button>`},
+		{Button(btn.With("size", "sm")), `<button class="btn btn-primary btn-sm text-sm"></button>`},
+		{Button(btn.With("size", "sm", "intent", "danger")), `<button class="btn btn-danger btn-sm text-sm font-bold"></button>`},
+		{Button(Var("intent", "danger"), btn.With()), `<button class="btn btn-danger btn-md"></button>`},
+		{Button(btn.With("size", "sm", "intent", "danger"), btn.With("size", "md")), `<button class="btn btn-danger btn-md"></button>`},
+		{Button(btn.With("unknown", "x", "size")).Class("extra"), `<button class="btn btn-primary btn-md extra"></button>`},
+	}
+	for i, c := range cases {
+		if got := c.n.String(); got != c.want {
+			t.Fatalf("case %d:\n got: %s\nwant: %s", i, got, c.want)
+		}
+		if i == 2 && c.n.GetVar("size").StringOrZero() != "sm" {
+			t.Fatalf("expected selected variant to be stored as a var")
+		}
+		c.n.Release()
+	}
+}